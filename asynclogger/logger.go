@@ -0,0 +1,166 @@
+package asynclogger
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "async"
+
+// OverflowPolicy is applied when an entry is logged while the queue is full
+type OverflowPolicy int8
+
+const (
+	// Block waits until the worker makes room in the queue
+	Block OverflowPolicy = iota
+	// DropNewest drops the entry being logged
+	DropNewest
+	// DropOldest drops the oldest entry of the queue
+	DropOldest
+	// DropBelowLevel drops the entry being logged if its level is below Config.DropLevel, waits otherwise
+	DropBelowLevel
+)
+
+// Config of an AsyncLogger
+type Config struct {
+	// Size is the number of entries the queue holds, DefaultSize if not set
+	Size int
+	// Policy is applied when the queue is full
+	Policy OverflowPolicy
+	// DropLevel is the level the entries are dropped below with the DropBelowLevel policy
+	DropLevel helpers.Level
+	// FlushInterval is the interval the target is synced at, e.g. to commit a file to the disk. Disabled if not set
+	FlushInterval time.Duration
+}
+
+// DefaultSize is the default number of entries of the queue
+const DefaultSize = 1024
+
+// AsyncLogger queues the entries and logs them with the target logger in a worker goroutine, so a slow output does not block the callers.
+// Fatal entries are logged synchronously after the queued ones. Call Close before exiting to log the pending entries.
+// The target stamps the entries with the time they are dequeued, which may be later than the time they were logged if the output is slow
+type AsyncLogger struct {
+	target helpers.ILogger
+	queue  *queue
+}
+
+var _ helpers.ILogger = (*AsyncLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*AsyncLogger)(nil)
+var _ helpers.CallerLogger = (*AsyncLogger)(nil)
+
+// NewAsyncLogger returns an asynchronous logger logging the entries with target
+func NewAsyncLogger(target helpers.ILogger, cfg Config) *AsyncLogger {
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultSize
+	}
+	return &AsyncLogger{target: target, queue: newQueue(cfg, target.Sync)}
+}
+
+// Target returns the logger the entries are logged with
+func (al *AsyncLogger) Target() helpers.ILogger { return al.target }
+
+// Dropped returns the number of entries dropped by the overflow policy
+func (al *AsyncLogger) Dropped() uint64 { return al.queue.dropped.Load() }
+
+// Flush waits until the queued entries are logged
+func (al *AsyncLogger) Flush() { al.queue.flush() }
+
+// Sync logs the queued entries and syncs the target
+func (al *AsyncLogger) Sync() error {
+	al.queue.flush()
+	return al.target.Sync()
+}
+
+// Close logs the queued entries, stops the worker goroutine of the logger and of its children and closes the target.
+// The entries logged afterwards are logged synchronously
+func (al *AsyncLogger) Close() error {
+	al.queue.close()
+	return al.target.Close()
+}
+
+func (al *AsyncLogger) LoggerName() string          { return LoggerName }
+func (al *AsyncLogger) GetLevel() string            { return al.target.GetLevel() }
+func (al *AsyncLogger) SetLevel(level string) error { return al.target.SetLevel(level) }
+func (al *AsyncLogger) GetWriter() *os.File         { return al.target.GetWriter() }
+func (al *AsyncLogger) GetOutput() io.Writer        { return al.target.GetOutput() }
+func (al *AsyncLogger) SetWriter(w *os.File)        { al.SetOutput(w) }
+func (al *AsyncLogger) Caller() bool                { return helpers.LogsCaller(al.target) }
+
+// SetOutput sets the output of the target after logging the queued entries
+func (al *AsyncLogger) SetOutput(w io.Writer) {
+	al.queue.flush()
+	al.target.SetOutput(w)
+}
+
+// Ctx returns an asynchronous logger of the child of the target, sharing the queue
+func (al *AsyncLogger) Ctx(ctx context.Context) helpers.ILogger {
+	return &AsyncLogger{target: al.target.Ctx(ctx), queue: al.queue}
+}
+
+// With returns an asynchronous logger of the child of the target, sharing the queue
+func (al *AsyncLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return al
+	}
+	return &AsyncLogger{target: al.target.With(details...), queue: al.queue}
+}
+
+// Named returns an asynchronous logger of the child of the target, sharing the queue
+func (al *AsyncLogger) Named(name string) helpers.ILogger {
+	return &AsyncLogger{target: al.target.Named(name), queue: al.queue}
+}
+
+// Fatal logs the queued entries and the fatal entry synchronously, then calls the fatal handler
+func (al *AsyncLogger) Fatal(msg string, details ...helpers.IDetails) {
+	al.queue.flush()
+	al.target.Fatal(msg, details...)
+}
+
+// PrintFatal logs the queued entries and the fatal entry synchronously without calling the fatal handler, see helpers.FatalPrinter
+func (al *AsyncLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	al.queue.flush()
+	helpers.PrintFatal(al.target, msg, details...)
+}
+
+func (al *AsyncLogger) Error(msg string, details ...helpers.IDetails) {
+	al.push(errorMethod, helpers.ErrorLevel, msg, details)
+}
+func (al *AsyncLogger) Warning(msg string, details ...helpers.IDetails) {
+	al.push(warningMethod, helpers.WarningLevel, msg, details)
+}
+func (al *AsyncLogger) Success(msg string, details ...helpers.IDetails) {
+	al.push(successMethod, helpers.SuccessLevel, msg, details)
+}
+func (al *AsyncLogger) Info(msg string, details ...helpers.IDetails) {
+	al.push(infoMethod, helpers.InfoLevel, msg, details)
+}
+func (al *AsyncLogger) Debug(msg string, details ...helpers.IDetails) {
+	al.push(debugMethod, helpers.DebugLevel, msg, details)
+}
+func (al *AsyncLogger) Start(msg string, details ...helpers.IDetails) {
+	al.push(startMethod, helpers.InfoLevel, msg, details)
+}
+func (al *AsyncLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	al.push(stopSuccessMethod, helpers.SuccessLevel, msg, details)
+}
+func (al *AsyncLogger) StopError(msg string, details ...helpers.IDetails) {
+	al.push(stopErrorMethod, helpers.ErrorLevel, msg, details)
+}
+
+func (al *AsyncLogger) push(m method, level helpers.Level, msg string, details []helpers.IDetails) {
+	// entries filtered by the target level do not take room in the queue
+	if targetLevel := helpers.ToLevel(al.target.GetLevel()); targetLevel != helpers.UnknownLevel && level.Skip(targetLevel) {
+		return
+	}
+	// the call site is resolved before the entry is queued, the worker goroutine has none
+	e := entry{target: al.target, method: m, level: level, msg: msg, details: helpers.AppendCallSite(level, details, helpers.LogsCaller(al.target))}
+	if !al.queue.push(e) {
+		// closed, the target resolves the call site
+		e.details = details
+		e.log()
+	}
+}
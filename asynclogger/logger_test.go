@@ -0,0 +1,208 @@
+package asynclogger
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+// gateWriter blocks the writes until the gate is opened
+type gateWriter struct {
+	mutex   sync.Mutex
+	b       bytes.Buffer
+	writing chan struct{} // receives a value when a write starts
+	gate    chan struct{}
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{writing: make(chan struct{}, 100), gate: make(chan struct{})}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.writing <- struct{}{}
+	<-w.gate
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.b.Write(p)
+}
+
+func (w *gateWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.b.String()
+}
+
+// newBlockedLogger returns an asynchronous logger whose worker is blocked writing the "first" entry
+func newBlockedLogger(t *testing.T, cfg Config) (*AsyncLogger, *gateWriter) {
+	prettylogger.DisableColor(true)
+	t.Cleanup(func() { prettylogger.EnableColor(true) })
+
+	w := newGateWriter()
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(w)
+	assert.NoError(t, target.SetLevel("debug"))
+	logger := NewAsyncLogger(target, cfg)
+	logger.Info("first")
+	<-w.writing
+	return logger, w
+}
+
+func TestAsyncLogger(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+
+	b := &bytes.Buffer{}
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(b)
+	logger := NewAsyncLogger(target, Config{})
+	assert.Equal(t, target, logger.Target())
+	assert.Equal(t, "info", logger.GetLevel())
+
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234"))
+	child.Debug("filtered")
+	child.Start("scanning")
+	child.Warning("slow")
+	child.StopSuccess("done")
+	logger.Flush()
+	assert.Equal(t, "[info] [scanner] scanning. scanID: 1234\n[warning] [scanner] slow. scanID: 1234\n[success] [scanner] done. scanID: 1234\n", b.String())
+
+	// logged synchronously once closed
+	assert.NoError(t, logger.Close())
+	b.Reset()
+	child.Info("closed")
+	assert.Equal(t, "[info] [scanner] closed. scanID: 1234\n", b.String())
+}
+
+func TestAsyncLoggerDropNewest(t *testing.T) {
+	logger, w := newBlockedLogger(t, Config{Size: 2, Policy: DropNewest})
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		logger.Info(msg)
+	}
+	assert.Equal(t, uint64(2), logger.Dropped())
+
+	close(w.gate)
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, "[info] first\n[info] a\n[info] b\n", w.String())
+}
+
+func TestAsyncLoggerDropOldest(t *testing.T) {
+	logger, w := newBlockedLogger(t, Config{Size: 2, Policy: DropOldest})
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		logger.Info(msg)
+	}
+	assert.Equal(t, uint64(2), logger.Dropped())
+
+	close(w.gate)
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, "[info] first\n[info] c\n[info] d\n", w.String())
+}
+
+func TestAsyncLoggerDropBelowLevel(t *testing.T) {
+	logger, w := newBlockedLogger(t, Config{Size: 2, Policy: DropBelowLevel, DropLevel: helpers.WarningLevel})
+	logger.Info("a")
+	logger.Error("b")
+	logger.Debug("c")
+	assert.Equal(t, uint64(1), logger.Dropped())
+
+	// the error waits for room in the queue
+	done := make(chan struct{})
+	go func() {
+		logger.Error("d")
+		close(done)
+	}()
+	close(w.gate)
+	<-done
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, uint64(1), logger.Dropped())
+	assert.Equal(t, "[info] first\n[info] a\n[error] b\n[error] d\n", w.String())
+}
+
+func TestAsyncLoggerBlock(t *testing.T) {
+	logger, w := newBlockedLogger(t, Config{Size: 1})
+	logger.Info("a")
+
+	done := make(chan struct{})
+	go func() {
+		logger.Info("b")
+		close(done)
+	}()
+	close(w.gate)
+	<-done
+	assert.NoError(t, logger.Sync())
+	assert.Equal(t, uint64(0), logger.Dropped())
+	assert.Equal(t, "[info] first\n[info] a\n[info] b\n", w.String())
+	assert.NoError(t, logger.Close())
+}
+
+func TestAsyncLoggerConcurrent(t *testing.T) {
+	b := &bytes.Buffer{}
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(b)
+	logger := NewAsyncLogger(target, Config{Size: 8})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("message")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, 1000, strings.Count(b.String(), "message"))
+}
+
+func TestAsyncLoggerCaller(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+	helpers.SetCaller(true)
+	defer helpers.SetCaller(false)
+
+	b := &bytes.Buffer{}
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(b)
+	logger := NewAsyncLogger(target, Config{})
+
+	// the call site is the caller of the async logger, queued or logged synchronously once closed
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info("async")
+	logger.Flush()
+	assert.NoError(t, logger.Close())
+	logger.Info("closed")
+
+	caller := fmt.Sprintf("caller: %s/%s:", path.Base(path.Dir(file)), path.Base(file))
+	function := "function: github.com/kubescape/go-logger/asynclogger.TestAsyncLoggerCaller"
+	assert.Equal(t, fmt.Sprintf("[info] async. %s%d; %s\n[info] closed. %s%d; %s\n", caller, line+1, function, caller, line+4, function), b.String())
+}
+
+func TestAsyncLoggerWithCaller(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+
+	// the call site enabled by the option of the target, not by helpers.SetCaller
+	b := &bytes.Buffer{}
+	logger := NewAsyncLogger(prettylogger.NewPrettyLogger(helpers.WithWriter(b), helpers.WithCaller(true)), Config{})
+	assert.True(t, logger.Caller())
+	_, file, line, _ := runtime.Caller(0)
+	logger.With(helpers.String("scanID", "1234")).Info("async")
+	assert.NoError(t, logger.Close())
+
+	caller := fmt.Sprintf("caller: %s/%s:%d", path.Base(path.Dir(file)), path.Base(file), line+1)
+	function := "function: github.com/kubescape/go-logger/asynclogger.TestAsyncLoggerWithCaller"
+	assert.Equal(t, fmt.Sprintf("[info] async. scanID: 1234; %s; %s\n", caller, function), b.String())
+
+	logger = NewAsyncLogger(prettylogger.NewPrettyLogger(helpers.WithWriter(b)), Config{})
+	defer logger.Close()
+	assert.False(t, logger.Caller())
+}
@@ -0,0 +1,196 @@
+package asynclogger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+// method of the logger an entry is logged with
+type method int8
+
+const (
+	errorMethod method = iota
+	warningMethod
+	successMethod
+	infoMethod
+	debugMethod
+	startMethod
+	stopSuccessMethod
+	stopErrorMethod
+)
+
+type entry struct {
+	target  helpers.ILogger
+	method  method
+	level   helpers.Level
+	msg     string
+	details []helpers.IDetails
+}
+
+func (e *entry) log() {
+	switch e.method {
+	case errorMethod:
+		e.target.Error(e.msg, e.details...)
+	case warningMethod:
+		e.target.Warning(e.msg, e.details...)
+	case successMethod:
+		e.target.Success(e.msg, e.details...)
+	case infoMethod:
+		e.target.Info(e.msg, e.details...)
+	case debugMethod:
+		e.target.Debug(e.msg, e.details...)
+	case startMethod:
+		e.target.Start(e.msg, e.details...)
+	case stopSuccessMethod:
+		e.target.StopSuccess(e.msg, e.details...)
+	case stopErrorMethod:
+		e.target.StopError(e.msg, e.details...)
+	}
+}
+
+// queue is a bounded ring buffer of entries, logged by a worker goroutine. It is shared by a logger and its children
+type queue struct {
+	cfg Config
+
+	mutex    sync.Mutex
+	notEmpty *sync.Cond // signaled when an entry is pushed or the queue is closed
+	notFull  *sync.Cond // signaled when entries are popped or the queue is closed
+	idle     *sync.Cond // signaled when the worker logged all the entries
+	entries  []entry
+	head     int // index of the oldest entry
+	count    int
+	busy     bool // the worker is logging entries popped from the queue
+	closed   bool
+
+	syncTarget func() error // called periodically
+	dropped    atomic.Uint64
+	done       chan struct{}
+}
+
+func newQueue(cfg Config, syncTarget func() error) *queue {
+	q := &queue{
+		cfg:        cfg,
+		entries:    make([]entry, cfg.Size),
+		syncTarget: syncTarget,
+		done:       make(chan struct{}),
+	}
+	q.notEmpty = sync.NewCond(&q.mutex)
+	q.notFull = sync.NewCond(&q.mutex)
+	q.idle = sync.NewCond(&q.mutex)
+	go q.run()
+	if cfg.FlushInterval > 0 {
+		go q.syncPeriodically()
+	}
+	return q
+}
+
+// push adds the entry to the queue, applying the overflow policy if it is full.
+// It returns false if the queue is closed, the entry must be logged synchronously
+func (q *queue) push(e entry) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for !q.closed && q.count == len(q.entries) {
+		switch {
+		case q.cfg.Policy == DropNewest, q.cfg.Policy == DropBelowLevel && e.level.Skip(q.cfg.DropLevel):
+			q.dropped.Add(1)
+			return true
+		case q.cfg.Policy == DropOldest:
+			q.entries[q.head] = entry{}
+			q.head = (q.head + 1) % len(q.entries)
+			q.count--
+			q.dropped.Add(1)
+		default:
+			q.notFull.Wait()
+		}
+	}
+	if q.closed {
+		return false
+	}
+	q.entries[(q.head+q.count)%len(q.entries)] = e
+	q.count++
+	q.notEmpty.Signal()
+	return true
+}
+
+// run logs the entries until the queue is closed and empty
+func (q *queue) run() {
+	defer close(q.done)
+	batch := make([]entry, 0, len(q.entries))
+	for {
+		q.mutex.Lock()
+		q.busy = false
+		if q.count == 0 {
+			q.idle.Broadcast()
+		}
+		for q.count == 0 && !q.closed {
+			q.notEmpty.Wait()
+		}
+		if q.count == 0 {
+			// closed
+			q.mutex.Unlock()
+			return
+		}
+		batch = batch[:0]
+		for ; q.count > 0; q.count-- {
+			batch = append(batch, q.entries[q.head])
+			q.entries[q.head] = entry{}
+			q.head = (q.head + 1) % len(q.entries)
+		}
+		q.busy = true
+		q.notFull.Broadcast()
+		q.mutex.Unlock()
+
+		for i := range batch {
+			batch[i].log()
+			batch[i] = entry{}
+		}
+	}
+}
+
+// flush waits until the worker logged all the entries pushed before the call
+func (q *queue) flush() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for (q.count > 0 || q.busy) && !q.isDone() {
+		q.idle.Wait()
+	}
+}
+
+// close logs the pending entries and stops the worker, the entries pushed afterwards are refused
+func (q *queue) close() {
+	q.mutex.Lock()
+	if !q.closed {
+		q.closed = true
+		q.notEmpty.Broadcast()
+		q.notFull.Broadcast()
+	}
+	q.mutex.Unlock()
+	<-q.done
+}
+
+func (q *queue) isDone() bool {
+	select {
+	case <-q.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// syncPeriodically syncs the target every flush interval until the queue is closed
+func (q *queue) syncPeriodically() {
+	ticker := time.NewTicker(q.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q.syncTarget()
+		case <-q.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+// contextKey is the key of the logger stored in a context
+type contextKey struct{}
+
+// IntoContext returns a copy of ctx carrying l. Functions receiving the context retrieve the logger with FromContext:
+//
+//	ctx = logger.IntoContext(ctx, logger.L().With(helpers.String("scanID", scanID)))
+//	...
+//	logger.FromContext(ctx).Info("scanning")
+func IntoContext(ctx context.Context, l helpers.ILogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or the global logger L() if ctx carries none.
+// Use FromContext(ctx).Ctx(ctx) to attach the logs to the span of ctx
+func FromContext(ctx context.Context) helpers.ILogger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(helpers.ILogger); ok && l != nil {
+			return l
+		}
+	}
+	return L()
+}
+
+// ContextWith returns a copy of ctx carrying the logger of ctx (see FromContext) enriched with the details
+func ContextWith(ctx context.Context, details ...helpers.IDetails) context.Context {
+	return IntoContext(ctx, FromContext(ctx).With(details...))
+}
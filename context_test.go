@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/nonelogger"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	none := nonelogger.NewNoneLogger()
+	defer ReplaceGlobals(none)()
+
+	// fallback to the global logger
+	assert.Same(t, none, FromContext(context.Background()))
+	assert.Same(t, none, FromContext(nil)) //nolint:staticcheck
+
+	pretty := prettylogger.NewPrettyLogger()
+	ctx := IntoContext(context.Background(), pretty)
+	assert.Same(t, pretty, FromContext(ctx))
+
+	// the logger follows derived contexts
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	assert.Same(t, pretty, FromContext(child))
+}
+
+func TestContextWith(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+
+	b := &bytes.Buffer{}
+	pretty := prettylogger.NewPrettyLogger()
+	pretty.SetOutput(b)
+
+	ctx := IntoContext(context.Background(), pretty)
+	ctx = ContextWith(ctx, helpers.String("tenant", "acme"))
+	ctx = ContextWith(ctx, helpers.String("scanID", "1234"))
+	FromContext(ctx).Info("scanning")
+
+	assert.Equal(t, "[info] scanning. tenant: acme; scanID: 1234\n", b.String())
+}
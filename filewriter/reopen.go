@@ -0,0 +1,45 @@
+//go:build !windows
+
+package filewriter
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ReopenOnSIGHUP reopens the file when the process receives SIGHUP, e.g. sent by logrotate after moving the file.
+// The returned function stops listening to the signal, as Close does
+func (w *FileWriter) ReopenOnSIGHUP() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-signals:
+				w.Reopen()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+	w.mutex.Lock()
+	closed := w.closed
+	if !closed {
+		w.stopReopen = append(w.stopReopen, stop)
+	}
+	w.mutex.Unlock()
+	if closed {
+		stop()
+	}
+	return stop
+}
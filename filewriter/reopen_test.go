@@ -0,0 +1,50 @@
+//go:build !windows
+
+package filewriter
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWriterReopenOnSIGHUP(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.log")
+	w, err := NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	defer w.Close()
+	stop := w.ReopenOnSIGHUP()
+	defer stop()
+
+	assert.NoError(t, os.Rename(filename, filename+".1"))
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filename)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestFileWriterCloseStopsReopenOnSIGHUP(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP) // the default action of SIGHUP terminates the process
+	defer signal.Stop(signals)
+
+	filename := filepath.Join(t.TempDir(), "a.log")
+	w, err := NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	w.ReopenOnSIGHUP()
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, os.Rename(filename, filename+".1"))
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	<-signals
+	assert.Never(t, func() bool {
+		_, err := os.Stat(filename)
+		return err == nil
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package filewriter
+
+// ReopenOnSIGHUP does nothing, there is no SIGHUP on Windows
+func (w *FileWriter) ReopenOnSIGHUP() (stop func()) {
+	return func() {}
+}
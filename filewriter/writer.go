@@ -0,0 +1,335 @@
+package filewriter
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the rotation time in the names of the backups, e.g. kubescape-2024-01-02T03-04-05.000.log
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+const compressSuffix = ".gz"
+
+// Config of a FileWriter, the zero values disable the corresponding rotation or retention
+type Config struct {
+	// Filename is the path of the file, its directory is created if missing
+	Filename string
+	// MaxSize is the size in bytes the file is rotated at
+	MaxSize int64
+	// Interval is the time the file is rotated after, e.g. 24h for a daily rotation
+	Interval time.Duration
+	// MaxAge is the time the backups are removed after
+	MaxAge time.Duration
+	// MaxBackups is the number of backups to keep
+	MaxBackups int
+	// Compress compresses the backups with gzip
+	Compress bool
+}
+
+// FileWriter is an io.Writer appending to a file and rotating it by size or time.
+// The rotated file is renamed to a backup with the rotation time in its name, the old backups are compressed and removed in the background.
+// It is safe to use concurrently and can be set as the output of any logger
+type FileWriter struct {
+	cfg Config
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool // set by Close, the file is not reopened
+
+	mill     chan struct{} // triggers the goroutine compressing and removing the backups, nil until the first rotation
+	millDone chan struct{}
+
+	stopReopen []func() // stop the goroutines started by ReopenOnSIGHUP
+
+	now func() time.Time // replaced in tests
+}
+
+var _ io.WriteCloser = (*FileWriter)(nil)
+
+// NewFileWriter opens or creates the file of the configuration
+func NewFileWriter(cfg Config) (*FileWriter, error) {
+	if cfg.Filename == "" {
+		return nil, errors.New("file name is empty")
+	}
+	if cfg.MaxSize < 0 || cfg.Interval < 0 || cfg.MaxAge < 0 || cfg.MaxBackups < 0 {
+		return nil, fmt.Errorf("invalid negative rotation configuration of '%s'", cfg.Filename)
+	}
+	w := &FileWriter{cfg: cfg, now: time.Now}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Filename returns the path of the file
+func (w *FileWriter) Filename() string { return w.cfg.Filename }
+
+// Write appends p to the file, rotating it first if p would exceed the max size or if the interval elapsed
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.size > 0 && (w.cfg.MaxSize > 0 && w.size+int64(len(p)) > w.cfg.MaxSize || w.cfg.Interval > 0 && w.now().Sub(w.openedAt) >= w.cfg.Interval) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file immediately
+func (w *FileWriter) Rotate() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return os.ErrClosed
+	}
+	return w.rotate()
+}
+
+// Reopen closes and reopens the file, e.g. after it was moved by an external tool such as logrotate
+func (w *FileWriter) Reopen() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return os.ErrClosed
+	}
+	if err := w.close(); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// Sync commits the content of the file to the disk
+func (w *FileWriter) Sync() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close closes the file, stops reopening it on SIGHUP and waits for the compression and removal of the backups.
+// Writing, rotating or reopening the closed file fails with os.ErrClosed
+func (w *FileWriter) Close() error {
+	w.mutex.Lock()
+	w.closed = true
+	err := w.close()
+	mill, millDone := w.mill, w.millDone
+	w.mill, w.millDone = nil, nil
+	stopReopen := w.stopReopen
+	w.stopReopen = nil
+	w.mutex.Unlock()
+
+	for _, stop := range stopReopen {
+		stop()
+	}
+	if mill != nil {
+		close(mill)
+		<-millDone
+	}
+	return err
+}
+
+func (w *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.cfg.Filename), 0755); err != nil {
+		return fmt.Errorf("failed to create the directory of '%s': %w", w.cfg.Filename, err)
+	}
+	f, err := os.OpenFile(w.cfg.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	if w.size == 0 || w.openedAt.IsZero() {
+		// the interval of a reopened file is not restarted
+		w.openedAt = w.now()
+	}
+	return nil
+}
+
+func (w *FileWriter) close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// rotate renames the file to a backup, opens a new file and triggers the compression and removal of the backups.
+// An empty file is not rotated, only its interval restarts
+func (w *FileWriter) rotate() error {
+	if w.file != nil && w.size == 0 {
+		w.openedAt = w.now()
+		return nil
+	}
+	if err := w.close(); err != nil {
+		return err
+	}
+	t := w.now()
+	backup := w.backupName(t)
+	for {
+		// two rotations in the same millisecond must not overwrite the first backup
+		if !exists(backup) && !exists(backup+compressSuffix) {
+			break
+		}
+		t = t.Add(time.Millisecond)
+		backup = w.backupName(t)
+	}
+	if err := os.Rename(w.cfg.Filename, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate '%s': %w", w.cfg.Filename, err)
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.triggerMill()
+	return nil
+}
+
+func (w *FileWriter) backupName(t time.Time) string {
+	dir, prefix, ext := w.nameParts()
+	return filepath.Join(dir, prefix+t.UTC().Format(backupTimeFormat)+ext)
+}
+
+// nameParts splits the file name in the directory, the backup prefix and the extension, e.g. "/var/log/", "kubescape-" and ".log"
+func (w *FileWriter) nameParts() (string, string, string) {
+	dir, name := filepath.Split(w.cfg.Filename)
+	ext := filepath.Ext(name)
+	return dir, strings.TrimSuffix(name, ext) + "-", ext
+}
+
+// triggerMill requests the mill goroutine to process the backups, starting it on the first rotation
+func (w *FileWriter) triggerMill() {
+	if w.cfg.MaxAge == 0 && w.cfg.MaxBackups == 0 && !w.cfg.Compress {
+		return
+	}
+	if w.mill == nil {
+		mill, millDone := make(chan struct{}, 1), make(chan struct{})
+		go func() {
+			defer close(millDone)
+			for range mill {
+				w.millRun()
+			}
+		}()
+		w.mill, w.millDone = mill, millDone
+	}
+	select {
+	case w.mill <- struct{}{}:
+	default: // a run is already pending
+	}
+}
+
+type backup struct {
+	path string
+	time time.Time
+}
+
+// millRun removes the backups exceeding the max backups or older than the max age and compresses the remaining ones
+func (w *FileWriter) millRun() {
+	backups, err := w.backups()
+	if err != nil {
+		return
+	}
+
+	var keep []backup
+	for i, b := range backups {
+		if w.cfg.MaxBackups > 0 && i >= w.cfg.MaxBackups || w.cfg.MaxAge > 0 && w.now().Sub(b.time) > w.cfg.MaxAge {
+			os.Remove(b.path)
+			continue
+		}
+		keep = append(keep, b)
+	}
+
+	if w.cfg.Compress {
+		for _, b := range keep {
+			if !strings.HasSuffix(b.path, compressSuffix) {
+				compressFile(b.path)
+			}
+		}
+	}
+}
+
+// backups returns the backups of the file, the most recent first
+func (w *FileWriter) backups() ([]backup, error) {
+	dir, prefix, ext := w.nameParts()
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []backup
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		ts := strings.TrimPrefix(e.Name(), prefix)
+		ts = strings.TrimSuffix(strings.TrimSuffix(ts, compressSuffix), ext)
+		t, err := time.Parse(backupTimeFormat, ts)
+		if err != nil {
+			continue // not a backup
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, e.Name()), time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].time.After(backups[j].time) })
+	return backups, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// compressFile replaces the file by its gzip compressed version
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + compressSuffix)
+		return err
+	}
+	if err := errors.Join(gz.Close(), dst.Close()); err != nil {
+		os.Remove(path + compressSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
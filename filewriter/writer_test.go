@@ -0,0 +1,158 @@
+package filewriter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// files returns the names of the files of dir, sorted
+func files(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func read(t *testing.T, path string) string {
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestNewFileWriter(t *testing.T) {
+	_, err := NewFileWriter(Config{})
+	assert.Error(t, err)
+	_, err = NewFileWriter(Config{Filename: filepath.Join(t.TempDir(), "a.log"), MaxBackups: -1})
+	assert.Error(t, err)
+
+	// the directory is created and the file is appended
+	filename := filepath.Join(t.TempDir(), "logs", "a.log")
+	w, err := NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	w.Write([]byte("first\n"))
+	assert.NoError(t, w.Close())
+
+	w, err = NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	w.Write([]byte("second\n"))
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "first\nsecond\n", read(t, filename))
+}
+
+func TestFileWriterMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	w, err := NewFileWriter(Config{Filename: filepath.Join(dir, "a.log"), MaxSize: 10, MaxBackups: 2})
+	assert.NoError(t, err)
+	w.now = func() time.Time { return now }
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		n, err := w.Write([]byte(line))
+		assert.NoError(t, err)
+		assert.Equal(t, len(line), n)
+		now = now.Add(time.Second)
+	}
+	assert.NoError(t, w.Close())
+
+	// the oldest backup is removed
+	assert.Equal(t, []string{"a-2024-01-02T03-04-07.000.log", "a-2024-01-02T03-04-08.000.log", "a.log"}, files(t, dir))
+	assert.Equal(t, "line 2\n", read(t, filepath.Join(dir, "a-2024-01-02T03-04-07.000.log")))
+	assert.Equal(t, "line 4\n", read(t, filepath.Join(dir, "a.log")))
+}
+
+func TestFileWriterInterval(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	w, err := NewFileWriter(Config{Filename: filepath.Join(dir, "a.log"), Interval: time.Hour, MaxAge: 90 * time.Minute})
+	assert.NoError(t, err)
+	w.now = func() time.Time { return now }
+	w.Rotate() // the file is empty, only restarts the interval
+
+	w.Write([]byte("line 1\n"))
+	now = now.Add(30 * time.Minute)
+	w.Write([]byte("line 2\n"))
+	now = now.Add(30 * time.Minute)
+	w.Write([]byte("line 3\n"))
+	assert.NoError(t, w.Close())
+	assert.Equal(t, []string{"a-2024-01-02T04-04-05.000.log", "a.log"}, files(t, dir))
+	assert.Equal(t, "line 1\nline 2\n", read(t, filepath.Join(dir, "a-2024-01-02T04-04-05.000.log")))
+
+	// the backup is removed after the max age
+	w, err = NewFileWriter(Config{Filename: filepath.Join(dir, "a.log"), Interval: time.Hour, MaxAge: 90 * time.Minute})
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	w.now = func() time.Time { return now }
+	assert.NoError(t, w.Rotate())
+	assert.NoError(t, w.Close())
+	assert.Equal(t, []string{"a-2024-01-02T06-04-05.000.log", "a.log"}, files(t, dir))
+}
+
+func TestFileWriterCompress(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewFileWriter(Config{Filename: filepath.Join(dir, "a.log"), Compress: true})
+	assert.NoError(t, err)
+	w.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	w.Write([]byte("line 1\n"))
+	assert.NoError(t, w.Rotate())
+	w.Write([]byte("line 2\n"))
+	// rotating twice in the same millisecond does not overwrite the backup
+	assert.NoError(t, w.Rotate())
+	assert.NoError(t, w.Close())
+	assert.Equal(t, []string{"a-2024-01-02T03-04-05.000.log.gz", "a-2024-01-02T03-04-05.001.log.gz", "a.log"}, files(t, dir))
+
+	f, err := os.Open(filepath.Join(dir, "a-2024-01-02T03-04-05.000.log.gz"))
+	assert.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.NoError(t, err)
+	b, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\n", string(b))
+}
+
+func TestFileWriterReopen(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a.log")
+	w, err := NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	defer w.Close()
+
+	w.Write([]byte("line 1\n"))
+	// moved by an external tool
+	assert.NoError(t, os.Rename(filename, filename+".1"))
+	assert.NoError(t, w.Reopen())
+	w.Write([]byte("line 2\n"))
+
+	assert.Equal(t, "line 1\n", read(t, filename+".1"))
+	assert.Equal(t, "line 2\n", read(t, filename))
+}
+
+func TestFileWriterClosed(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.log")
+	w, err := NewFileWriter(Config{Filename: filename})
+	assert.NoError(t, err)
+	w.Write([]byte("line 1\n"))
+	assert.NoError(t, w.Close())
+	assert.NoError(t, w.Close())
+
+	// the closed file is not reopened
+	_, err = w.Write([]byte("line 2\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.ErrorIs(t, w.Rotate(), os.ErrClosed)
+	assert.ErrorIs(t, w.Reopen(), os.ErrClosed)
+	assert.NoError(t, w.Sync())
+	assert.Equal(t, []string{"a.log"}, files(t, filepath.Dir(filename)))
+	assert.Equal(t, "line 1\n", read(t, filename))
+}
@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// modulePath is the prefix of the functions of go-logger, skipped when looking for the call site
+const modulePath = "github.com/kubescape/go-logger"
+
+// callerSkipPackages are the runtime, e.g. the runtime.goexit frame of the goroutines, and the packages forwarding their entries to the loggers, their frames are skipped too
+var callerSkipPackages = []string{"runtime", "log/slog", "github.com/go-logr/logr"}
+
+var (
+	callerEnabled     atomic.Bool
+	stacktraceEnabled atomic.Bool
+)
+
+// SetCaller adds the call site of the entries as "caller" (file:line) and "function" details
+func SetCaller(enabled bool) {
+	callerEnabled.Store(enabled)
+}
+
+// SetStacktrace adds the call stack of the error and fatal entries as the "stacktrace" detail.
+// It is not added to the entries logging an error with the stack captured by Error (see SetErrorStacks), the stacks would be almost the same
+func SetStacktrace(enabled bool) {
+	stacktraceEnabled.Store(enabled)
+}
+
+// CallerLogger is implemented by the loggers which can add the call site of their entries even if it is not enabled by SetCaller, see WithCaller
+type CallerLogger interface {
+	// Caller reports whether the logger adds the call site of its entries
+	Caller() bool
+}
+
+// LogsCaller reports whether l adds the call site of its entries, false if it is not a CallerLogger
+func LogsCaller(l ILogger) bool {
+	c, ok := l.(CallerLogger)
+	return ok && c.Caller()
+}
+
+// AppendCallSite appends the call site details enabled by SetCaller and SetStacktrace to the details of an entry of the level,
+// caller adds the call site even if it is not enabled by SetCaller, e.g. LogsCaller of the logger the entry is logged with.
+// The loggers logging their entries on another goroutine, e.g. the async logger, call it on the goroutine of the caller
+func AppendCallSite(level Level, details []IDetails, caller bool) []IDetails {
+	return appendCallSite(level, details, caller)
+}
+
+// appendCallSite appends the call site details to the details of an entry of the level, caller adds the call site even if it is not enabled by SetCaller
+func appendCallSite(level Level, details []IDetails, caller bool) []IDetails {
+	if level == UnknownLevel {
+		return details
+	}
+	caller, stacktrace := caller || callerEnabled.Load(), stacktraceEnabled.Load() && !level.Skip(ErrorLevel) && !hasErrorStack(details)
+	if !caller && !stacktrace {
+		return details
+	}
+	frames := callSite(stacktrace)
+	if len(frames) == 0 {
+		return details
+	}
+	details = append(make([]IDetails, 0, len(details)+3), details...)
+	if caller {
+		details = append(details, String("caller", shortCaller(frames[0])), String("function", frames[0].Function))
+	}
+	if stacktrace {
+		details = append(details, String("stacktrace", formatFrames(frames)))
+	}
+	return details
+}
+
+// hasErrorStack reports whether the details, before ExpandErrors, hold an error with the stack captured by Error
+func hasErrorStack(details []IDetails) bool {
+	for i := range details {
+		switch d := details[i].(type) {
+		case *ErrorObj:
+			if d.value != nil && len(d.stack) > 0 {
+				return true
+			}
+		case *GroupObj:
+			if hasErrorStack(d.Details()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callSite returns the frame of the call site of the logger, followed by the frames of its callers if stack is set.
+// The frames of go-logger, except in the test files, and of the packages forwarding their entries to the loggers are skipped
+func callSite(stack bool) []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	it := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var frames []runtime.Frame
+	for {
+		frame, more := it.Next()
+		if len(frames) > 0 || !isLoggerFrame(frame) {
+			frames = append(frames, frame)
+			if !stack || len(frames) == maxStackDepth {
+				return frames
+			}
+		}
+		if !more {
+			return frames
+		}
+	}
+}
+
+func isLoggerFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, modulePath) {
+		rest := frame.Function[len(modulePath):]
+		return (strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "/")) && !strings.HasSuffix(frame.File, "_test.go")
+	}
+	for _, pkg := range callerSkipPackages {
+		if strings.HasPrefix(frame.Function, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// shortCaller returns the directory, file and line of the frame, e.g. "scanner/scan.go:42"
+func shortCaller(frame runtime.Frame) string {
+	return path.Base(path.Dir(frame.File)) + "/" + path.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// componentLevels maps a component name to its level. The map is never modified once stored, updates swap it
+var componentLevels atomic.Pointer[map[string]Level]
+var componentLevelsMutex sync.Mutex
+
+// JoinNames joins the name of a parent logger with the name of a sub-component, e.g. "kubescape" and "scanner" -> "kubescape.scanner"
+func JoinNames(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	}
+	return parent + "." + name
+}
+
+// SetComponentLevel sets the level of the logger named component and of all its sub-components, independently of the level of the root logger.
+// e.g. SetComponentLevel("kubescape.scanner", "debug") applies to "kubescape.scanner" and "kubescape.scanner.rbac" but not to "kubescape.scannerx"
+func SetComponentLevel(component, level string) error {
+	l := ToLevel(level)
+	if l == UnknownLevel {
+		return fmt.Errorf("level '%s' unknown", level)
+	}
+	updateComponentLevels(func(levels map[string]Level) { levels[component] = l })
+	return nil
+}
+
+// UnsetComponentLevel removes the level set for component, the component will fall back to the level of its parent component or of the root logger
+func UnsetComponentLevel(component string) {
+	updateComponentLevels(func(levels map[string]Level) { delete(levels, component) })
+}
+
+// ResetComponentLevels removes all component levels
+func ResetComponentLevels() {
+	componentLevelsMutex.Lock()
+	defer componentLevelsMutex.Unlock()
+	componentLevels.Store(nil)
+}
+
+// ComponentLevel returns the level set for the closest component of the named logger
+func ComponentLevel(name string) (Level, bool) {
+	levels := componentLevels.Load()
+	if levels == nil || name == "" {
+		return UnknownLevel, false
+	}
+	for {
+		if l, ok := (*levels)[name]; ok {
+			return l, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return UnknownLevel, false
+		}
+		name = name[:i]
+	}
+}
+
+// MinComponentLevel returns the lowest level set for a component
+func MinComponentLevel() (Level, bool) {
+	levels := componentLevels.Load()
+	if levels == nil || len(*levels) == 0 {
+		return UnknownLevel, false
+	}
+	min := _maxLevel
+	for _, l := range *levels {
+		if l < min {
+			min = l
+		}
+	}
+	return min, true
+}
+
+// EffectiveLevel returns the level of the named logger, which is the component level if set and the level of the root logger otherwise
+func EffectiveLevel(name string, level Level) Level {
+	if l, ok := ComponentLevel(name); ok {
+		return l
+	}
+	return level
+}
+
+func updateComponentLevels(update func(map[string]Level)) {
+	componentLevelsMutex.Lock()
+	defer componentLevelsMutex.Unlock()
+
+	levels := map[string]Level{}
+	if current := componentLevels.Load(); current != nil {
+		for k, v := range *current {
+			levels[k] = v
+		}
+	}
+	update(levels)
+	componentLevels.Store(&levels)
+}
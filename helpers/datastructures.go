@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"strings"
 	"time"
 )
@@ -19,6 +20,7 @@ var _ IDetails = (*ErrorObj)(nil)
 type ErrorObj struct {
 	key   string
 	value error
+	stack []uintptr // call stack of Error, captured if enabled by SetErrorStacks
 }
 
 var _ IDetails = (*IntObj)(nil)
@@ -35,12 +37,130 @@ type InterfaceObj struct {
 	value interface{}
 }
 
-func Error(e error) *ErrorObj     { return &ErrorObj{key: "error", value: e} }
+var _ IDetails = (*BoolObj)(nil)
+
+type BoolObj struct {
+	key   string
+	value bool
+}
+
+var _ IDetails = (*Int64Obj)(nil)
+
+type Int64Obj struct {
+	key   string
+	value int64
+}
+
+var _ IDetails = (*Uint64Obj)(nil)
+
+type Uint64Obj struct {
+	key   string
+	value uint64
+}
+
+var _ IDetails = (*Float64Obj)(nil)
+
+type Float64Obj struct {
+	key   string
+	value float64
+}
+
+var _ IDetails = (*DurationObj)(nil)
+
+type DurationObj struct {
+	key   string
+	value time.Duration
+}
+
+var _ IDetails = (*TimeObj)(nil)
+
+type TimeObj struct {
+	key   string
+	value time.Time
+}
+
+var _ IDetails = (*StringsObj)(nil)
+
+type StringsObj struct {
+	key   string
+	value []string
+}
+
+var _ IDetails = (*IntsObj)(nil)
+
+type IntsObj struct {
+	key   string
+	value []int
+}
+
+var _ IDetails = (*ByteSizeObj)(nil)
+
+type ByteSizeObj struct {
+	key   string
+	value Bytes
+}
+
+var _ IDetails = (*StringerObj)(nil)
+
+type StringerObj struct {
+	key   string
+	value fmt.Stringer
+}
+
+var _ IDetails = (*GroupObj)(nil)
+
+type GroupObj struct {
+	key   string
+	value []IDetails
+}
+
+var _ IDetails = (*SecretObj)(nil)
+
+// SecretObj is a detail whose value is never logged, see Secret
+type SecretObj struct {
+	key string
+}
+
+// ObjectMarshaler is implemented by types logged as a group of details, see Object
+type ObjectMarshaler interface {
+	MarshalDetails() []IDetails
+}
+
 func Int(k string, v int) *IntObj { return &IntObj{key: k, value: v} }
 func String(k, v string) *StringObj {
 	return &StringObj{key: k, value: strings.ToValidUTF8(v, InvalidUtf8ReplacementString)}
 }
 func Interface(k string, v interface{}) *InterfaceObj { return &InterfaceObj{key: k, value: v} }
+func Bool(k string, v bool) *BoolObj                  { return &BoolObj{key: k, value: v} }
+func Int64(k string, v int64) *Int64Obj               { return &Int64Obj{key: k, value: v} }
+func Uint64(k string, v uint64) *Uint64Obj            { return &Uint64Obj{key: k, value: v} }
+func Float64(k string, v float64) *Float64Obj         { return &Float64Obj{key: k, value: v} }
+func Duration(k string, v time.Duration) *DurationObj { return &DurationObj{key: k, value: v} }
+func TimeValue(k string, v time.Time) *TimeObj        { return &TimeObj{key: k, value: v} }
+func Ints(k string, v []int) *IntsObj                 { return &IntsObj{key: k, value: v} }
+func ByteSize(k string, v int64) *ByteSizeObj         { return &ByteSizeObj{key: k, value: Bytes(v)} }
+func Stringer(k string, v fmt.Stringer) *StringerObj  { return &StringerObj{key: k, value: v} }
+func Secret(k, v string) *SecretObj                   { return &SecretObj{key: k} }
+func Group(k string, details ...IDetails) *GroupObj   { return &GroupObj{key: k, value: details} }
+func Object(k string, v ObjectMarshaler) *GroupObj {
+	if v == nil {
+		return Group(k)
+	}
+	return Group(k, v.MarshalDetails()...)
+}
+func Error(e error) *ErrorObj {
+	if e == nil || !errorStacks.Load() {
+		return &ErrorObj{key: "error", value: e}
+	}
+	return &ErrorObj{key: "error", value: e, stack: callers(1)}
+}
+func Strings(k string, v []string) *StringsObj {
+	valid := make([]string, len(v))
+	for i := range v {
+		valid[i] = strings.ToValidUTF8(v[i], InvalidUtf8ReplacementString)
+	}
+	return &StringsObj{key: k, value: valid}
+}
 func Time() *StringObj {
 	return &StringObj{key: "time", value: time.Now().Format("2006-01-02 15:04:05")}
 }
@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a log entry, as passed to the hooks of the hook logger
+type Entry struct {
+	Level Level
+	// Event of the entries logged by Start, StopSuccess and StopError (e.g. StartEvent), empty otherwise
+	Event   string
+	Message string
+	Details []IDetails
+	// Time of the call, informational only: the loggers stamp the entries with their own time and ignore changes made by the hooks
+	Time time.Time
+	// Ctx is the context of the logger, see ILogger.Ctx. context.Background() if not set
+	Ctx context.Context
+	// Name is the component name of the logger, see ILogger.Named
+	Name string
+}
+
+// WithDetails returns a copy of the entry with the details appended
+func (e Entry) WithDetails(details ...IDetails) Entry {
+	e.Details = append(append(make([]IDetails, 0, len(e.Details)+len(details)), e.Details...), details...)
+	return e
+}
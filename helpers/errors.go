@@ -0,0 +1,143 @@
+package helpers
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// maxErrorChain is the maximum number of errors of a chain, in case of a cycle
+const maxErrorChain = 32
+
+// maxStackDepth is the maximum number of frames of a stack trace
+const maxStackDepth = 32
+
+var errorStacks atomic.Bool
+
+// SetErrorStacks enables the capture of the call stack by Error, the stack is logged with the entries of the error and fatal levels as "errorStack"
+func SetErrorStacks(enabled bool) {
+	errorStacks.Store(enabled)
+}
+
+// ErrorChain returns the messages of the error and of the errors it wraps, depth first (see errors.Unwrap and errors.Join)
+func ErrorChain(err error) []string {
+	var chain []string
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil || len(chain) == maxErrorChain {
+			return
+		}
+		chain = append(chain, err.Error())
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range e.Unwrap() {
+				walk(wrapped)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// ExpandErrors adds the details of the errors to the details, the loggers call it before rendering the details:
+//   - "<key>Chain": the messages of the wrapped errors (see ErrorChain), if the error wraps other errors
+//   - "<key>Verbose": the error formatted with %+v, if it implements fmt.Formatter (e.g. github.com/pkg/errors) and %+v adds information
+//   - "<key>Stack": the call stack of Error (see SetErrorStacks), if the entry level is error or fatal
+//
+// The error itself is logged as its message. The slice is returned as is if there is nothing to add
+func ExpandErrors(level Level, details []IDetails) []IDetails {
+	expanded, _ := expandErrors(level, details)
+	return expanded
+}
+
+func expandErrors(level Level, details []IDetails) ([]IDetails, bool) {
+	var expanded []IDetails // allocated on the first expanded error
+	for i := range details {
+		var extra []IDetails
+		switch d := details[i].(type) {
+		case *ErrorObj:
+			extra = d.expand(level)
+		case *GroupObj:
+			if group, ok := expandErrors(level, d.Details()); ok {
+				extra = []IDetails{Group(d.Key(), group...)}
+			}
+		}
+		if extra != nil && expanded == nil {
+			expanded = append(make([]IDetails, 0, len(details)+len(extra)), details[:i]...)
+		}
+		switch {
+		case extra != nil:
+			expanded = append(expanded, extra...)
+		case expanded != nil:
+			expanded = append(expanded, details[i])
+		}
+	}
+	if expanded == nil {
+		return details, false
+	}
+	return expanded, true
+}
+
+// expand returns the details of the error, or nil if it has no other details than its message
+func (s *ErrorObj) expand(level Level) []IDetails {
+	if s.value == nil {
+		return nil
+	}
+	var details []IDetails
+	if chain := ErrorChain(s.value); len(chain) > 1 {
+		details = append(details, Strings(s.key+"Chain", chain))
+	}
+	if _, ok := s.value.(fmt.Formatter); ok {
+		if verbose := fmt.Sprintf("%+v", s.value); verbose != s.value.Error() {
+			details = append(details, String(s.key+"Verbose", verbose))
+		}
+	}
+	if len(s.stack) > 0 && !level.Skip(ErrorLevel) {
+		details = append(details, String(s.key+"Stack", formatStack(s.stack)))
+	}
+	if details == nil {
+		return nil
+	}
+	return append([]IDetails{String(s.key, s.value.Error())}, details...)
+}
+
+// callers returns the call stack of its caller, skipping skip frames
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(skip+2, pcs)]
+}
+
+// formatStack formats the stack as in the Go stack traces, a function and its location per frame
+func formatStack(pcs []uintptr) string {
+	var frames []runtime.Frame
+	it := runtime.CallersFrames(pcs)
+	for {
+		frame, more := it.Next()
+		frames = append(frames, frame)
+		if !more {
+			return formatFrames(frames)
+		}
+	}
+}
+
+func formatFrames(frames []runtime.Frame) string {
+	b := &strings.Builder{}
+	for i := range frames {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(b, "%s\n\t%s:%d", frames[i].Function, frames[i].File, frames[i].Line)
+	}
+	return b.String()
+}
+
+// PrepareDetails expands the errors (see ExpandErrors), adds the call site (see SetCaller and SetStacktrace) and redacts the details (see Redact).
+// The loggers call it before rendering the details of an entry of the level, or with UnknownLevel for the details of a child logger.
+// caller adds the call site even if it is not enabled by SetCaller, see WithCaller
+func PrepareDetails(level Level, details []IDetails, caller bool) []IDetails {
+	// the call site is appended first, the errors carrying a stack skip the stack trace
+	return Redact(ExpandErrors(level, appendCallSite(level, details, caller)))
+}
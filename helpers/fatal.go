@@ -0,0 +1,216 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// FatalHandler is called by Fatal once the entry is logged and the fatal hooks ran. It should not return, the caller of Fatal continues if it does
+type FatalHandler func(msg string)
+
+// DefaultFatalHookTimeout is the default time the fatal hooks have to run
+const DefaultFatalHookTimeout = 5 * time.Second
+
+var (
+	fatalMutex       sync.Mutex
+	fatalHandler     FatalHandler = ExitFatalHandler(1)
+	fatalHooks       []func(ctx context.Context)
+	fatalHookTimeout = DefaultFatalHookTimeout
+	currentFatalRun  *fatalRun // run of the hooks in progress, nil if none
+)
+
+// ExitFatalHandler returns a handler exiting with the code, the default handler exits with 1
+func ExitFatalHandler(code int) FatalHandler {
+	return func(string) { os.Exit(code) }
+}
+
+// PanicFatalHandler returns a handler panicking with a FatalError instead of exiting, so deferred functions run and the panic can be recovered
+func PanicFatalHandler() FatalHandler {
+	return func(msg string) { panic(FatalError{Msg: msg}) }
+}
+
+// FatalError is the value PanicFatalHandler panics with
+type FatalError struct {
+	Msg string
+}
+
+func (e FatalError) Error() string { return fmt.Sprintf("fatal: %s", e.Msg) }
+
+// SetFatalHandler sets the handler called by Fatal, nil restores the default handler exiting with 1
+func SetFatalHandler(h FatalHandler) {
+	if h == nil {
+		h = ExitFatalHandler(1)
+	}
+	fatalMutex.Lock()
+	fatalHandler = h
+	fatalMutex.Unlock()
+}
+
+// ReplaceFatalHandler sets the handler called by Fatal and returns a function restoring the previous one, e.g. in tests:
+//
+//	recorder := helpers.NewFatalRecorder()
+//	defer helpers.ReplaceFatalHandler(recorder.Handle)()
+func ReplaceFatalHandler(h FatalHandler) func() {
+	fatalMutex.Lock()
+	previous := fatalHandler
+	fatalMutex.Unlock()
+	SetFatalHandler(h)
+	return func() { SetFatalHandler(previous) }
+}
+
+// RegisterFatalHook registers a function run by Fatal before the handler, e.g. to flush the telemetry.
+// The hooks run in their registration order and share the timeout set by SetFatalHookTimeout, the remaining hooks are skipped once it expires
+func RegisterFatalHook(hook func(ctx context.Context)) {
+	fatalMutex.Lock()
+	fatalHooks = append(fatalHooks, hook)
+	fatalMutex.Unlock()
+}
+
+// ResetFatalHooks removes the registered fatal hooks
+func ResetFatalHooks() {
+	fatalMutex.Lock()
+	fatalHooks = nil
+	fatalMutex.Unlock()
+}
+
+// SetFatalHookTimeout sets the time the fatal hooks have to run, DefaultFatalHookTimeout by default
+func SetFatalHookTimeout(timeout time.Duration) {
+	fatalMutex.Lock()
+	fatalHookTimeout = timeout
+	fatalMutex.Unlock()
+}
+
+// fatalRun is the run of the fatal hooks by the first of the concurrent Fatal calls, the others wait for it
+type fatalRun struct {
+	done     chan struct{}
+	deadline time.Time
+}
+
+// fatalHookRunner is the function running the fatal hooks, a Fatal called from a hook has it in its call stack
+const fatalHookRunner = modulePath + "/helpers.runFatalHookChain"
+
+// HandleFatal runs the fatal hooks and calls the fatal handler, the loggers call it once the fatal entry is logged and synced.
+// The concurrent Fatal calls wait for the hooks run by the first one, up to the same timeout, before calling the handler.
+// The hooks are not run again by a Fatal called from a hook, it calls the handler right away
+func HandleFatal(msg string) {
+	fatalMutex.Lock()
+	handler, hooks, timeout := fatalHandler, fatalHooks, fatalHookTimeout
+	if calledFromFatalHook() {
+		fatalMutex.Unlock()
+		handler(msg)
+		return
+	}
+	run := currentFatalRun
+	if run != nil {
+		fatalMutex.Unlock()
+		select {
+		case <-run.done:
+		case <-time.After(time.Until(run.deadline)):
+		}
+		handler(msg)
+		return
+	}
+	run = &fatalRun{done: make(chan struct{}), deadline: time.Now().Add(timeout)}
+	currentFatalRun = run
+	fatalMutex.Unlock()
+
+	runFatalHooks(hooks, timeout)
+
+	fatalMutex.Lock()
+	currentFatalRun = nil
+	fatalMutex.Unlock()
+	close(run.done)
+	handler(msg)
+}
+
+func runFatalHooks(hooks []func(ctx context.Context), timeout time.Duration) {
+	if len(hooks) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go runFatalHookChain(ctx, hooks, done)
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// runFatalHookChain runs the hooks until the context expires and closes done
+func runFatalHookChain(ctx context.Context, hooks []func(ctx context.Context), done chan<- struct{}) {
+	defer close(done)
+	for _, hook := range hooks {
+		if ctx.Err() != nil {
+			return
+		}
+		hook(ctx)
+	}
+}
+
+// calledFromFatalHook returns true if runFatalHookChain is in the call stack
+func calledFromFatalHook() bool {
+	pcs := make([]uintptr, 128)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.Function == fatalHookRunner {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
+
+// FatalPrinter is implemented by the loggers able to log a fatal entry without calling the fatal handler,
+// e.g. for the multi logger logging the entry with all its loggers before calling the handler once
+type FatalPrinter interface {
+	// PrintFatal logs the entry at the fatal level and syncs the output, without calling the fatal handler
+	PrintFatal(msg string, details ...IDetails)
+}
+
+// PrintFatal logs the fatal entry with l without calling the fatal handler, at the error level if l is not a FatalPrinter
+func PrintFatal(l ILogger, msg string, details ...IDetails) {
+	if p, ok := l.(FatalPrinter); ok {
+		p.PrintFatal(msg, details...)
+		return
+	}
+	l.Error(msg, details...)
+	l.Sync()
+}
+
+// FatalRecorder is a FatalHandler recording the calls instead of exiting, for tests
+type FatalRecorder struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func NewFatalRecorder() *FatalRecorder {
+	return &FatalRecorder{}
+}
+
+// Handle records the message, use it as the fatal handler
+func (r *FatalRecorder) Handle(msg string) {
+	r.mutex.Lock()
+	r.messages = append(r.messages, msg)
+	r.mutex.Unlock()
+}
+
+// Messages returns the messages of the recorded Fatal calls
+func (r *FatalRecorder) Messages() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.messages...)
+}
+
+// SetFatalTestMode records the Fatal calls instead of exiting until the returned function is called
+func SetFatalTestMode() (*FatalRecorder, func()) {
+	recorder := NewFatalRecorder()
+	return recorder, ReplaceFatalHandler(recorder.Handle)
+}
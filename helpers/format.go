@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bytes is a size in bytes, printed in human readable units (e.g. "1.5 MiB")
+type Bytes int64
+
+func (b Bytes) String() string {
+	const unit = 1024
+	if b < unit && b > -unit {
+		return fmt.Sprintf("%d B", int64(b))
+	}
+	div, exp := int64(unit), 0
+	for n := int64(b) / unit; n >= unit || n <= -unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatValue formats a detail value for the human friendly loggers
+func FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case []int:
+		s := make([]string, len(v))
+		for i := range v {
+			s[i] = strconv.Itoa(v[i])
+		}
+		return "[" + strings.Join(s, ", ") + "]"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Flatten replaces the groups of details by their details, prefixing their keys with the group key (e.g. "resource.name")
+func Flatten(details []IDetails) []IDetails {
+	hasGroup := false
+	for i := range details {
+		if _, ok := details[i].(*GroupObj); ok {
+			hasGroup = true
+			break
+		}
+	}
+	if !hasGroup {
+		return details
+	}
+	return flatten(make([]IDetails, 0, len(details)), "", details)
+}
+
+func flatten(flat []IDetails, prefix string, details []IDetails) []IDetails {
+	for i := range details {
+		key := JoinNames(prefix, details[i].Key())
+		if group, ok := details[i].(*GroupObj); ok {
+			flat = flatten(flat, key, group.Details())
+			continue
+		}
+		if prefix == "" {
+			flat = append(flat, details[i])
+		} else {
+			flat = append(flat, Interface(key, details[i].Value()))
+		}
+	}
+	return flat
+}
@@ -2,12 +2,13 @@ package helpers
 
 import (
 	"context"
+	"io"
 	"os"
 )
 
 // ILogger interface moved here to prevent import cycles
 type ILogger interface {
-	Fatal(msg string, details ...IDetails) // print log and exit 1
+	Fatal(msg string, details ...IDetails) // print log and call the fatal handler, exit 1 by default (see SetFatalHandler)
 	Error(msg string, details ...IDetails)
 	Success(msg string, details ...IDetails)
 	Warning(msg string, details ...IDetails)
@@ -21,8 +22,16 @@ type ILogger interface {
 	GetLevel() string
 
 	SetWriter(w *os.File)
-	GetWriter() *os.File
+	GetWriter() *os.File // nil if the output is not a file
+
+	SetOutput(w io.Writer) // like SetWriter, for any io.Writer
+	GetOutput() io.Writer
 
 	Ctx(ctx context.Context) ILogger
+	With(details ...IDetails) ILogger // child logger adding details to every entry, shares level and writer with its parent
+	Named(name string) ILogger        // child logger of a sub-component, names are joined with dots (see SetComponentLevel)
 	LoggerName() string
+
+	Sync() error  // flush the buffered entries and commit the output to its storage, e.g. files
+	Close() error // flush and release the resources of the logger, e.g. close its output
 }
@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// TextFormat is the human friendly output of the pretty and icon loggers
+	TextFormat = "text"
+	// JSONFormat prints one JSON object per line
+	JSONFormat = "json"
+)
+
+// Events of the entries logged by Start, StopSuccess and StopError, encoded in the "event" field of the JSON format
+const (
+	StartEvent       = "start"
+	StopSuccessEvent = "stop_success"
+	StopErrorEvent   = "stop_error"
+)
+
+// ToFormat returns the output format matching format, or an error if it is not supported
+func ToFormat(format string) (string, error) {
+	switch format {
+	case "", TextFormat, "human":
+		return TextFormat, nil
+	case JSONFormat:
+		return JSONFormat, nil
+	}
+	return "", fmt.Errorf("format '%s' unknown", format)
+}
+
+// JSONLineWithTimeFormat encodes an entry as a JSON object terminated by a new line, formatting the time with the layout, e.g. time.RFC3339.
+// The logger name and the event (e.g. "start") are omitted when empty
+func JSONLineWithTimeFormat(layout string, level Level, t time.Time, name, event, msg string, details []IDetails) []byte {
+	b := &bytes.Buffer{}
+	b.WriteString(`{"level":`)
+	writeJSON(b, level.String())
+	b.WriteString(`,"time":`)
+	writeJSON(b, t.Format(layout))
+	if name != "" {
+		b.WriteString(`,"logger":`)
+		writeJSON(b, name)
+	}
+	if event != "" {
+		b.WriteString(`,"event":`)
+		writeJSON(b, event)
+	}
+	b.WriteString(`,"msg":`)
+	writeJSON(b, msg)
+	if len(details) > 0 {
+		b.WriteByte(',')
+		writeJSONDetails(b, details)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func writeJSONDetails(b *bytes.Buffer, details []IDetails) {
+	for i := range details {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeJSON(b, details[i].Key())
+		b.WriteByte(':')
+		if group, ok := details[i].(*GroupObj); ok {
+			b.WriteByte('{')
+			writeJSONDetails(b, group.Details())
+			b.WriteByte('}')
+			continue
+		}
+		writeJSON(b, jsonValue(details[i].Value()))
+	}
+}
+
+// jsonValue converts the values encoding.json does not encode like zap does
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Duration:
+		return v.Seconds()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case Bytes:
+		return int64(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return v
+}
+
+func writeJSON(b *bytes.Buffer, v interface{}) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		enc.Encode(fmt.Sprintf("%v", v))
+	}
+	// Encode terminates the value with a new line
+	b.Truncate(b.Len() - 1)
+}
@@ -60,3 +60,176 @@ func (s *InterfaceObj) Key() string {
 func (s *InterfaceObj) Value() interface{} {
 	return s.value
 }
+
+// ======================================================================================
+// ================================ Bool ================================================
+// ======================================================================================
+
+// Key
+func (s *BoolObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *BoolObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// =============================== Int64 ================================================
+// ======================================================================================
+
+// Key
+func (s *Int64Obj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *Int64Obj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================== Uint64 ================================================
+// ======================================================================================
+
+// Key
+func (s *Uint64Obj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *Uint64Obj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================= Float64 ================================================
+// ======================================================================================
+
+// Key
+func (s *Float64Obj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *Float64Obj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================ Duration ================================================
+// ======================================================================================
+
+// Key
+func (s *DurationObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *DurationObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ================================ Time ================================================
+// ======================================================================================
+
+// Key
+func (s *TimeObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *TimeObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================= Strings ================================================
+// ======================================================================================
+
+// Key
+func (s *StringsObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *StringsObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ================================ Ints ================================================
+// ======================================================================================
+
+// Key
+func (s *IntsObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *IntsObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================ ByteSize ================================================
+// ======================================================================================
+
+// Key
+func (s *ByteSizeObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *ByteSizeObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// ============================ Stringer ================================================
+// ======================================================================================
+
+// Key
+func (s *StringerObj) Key() string {
+	return s.key
+}
+
+// Value
+func (s *StringerObj) Value() interface{} {
+	return s.value
+}
+
+// ======================================================================================
+// =============================== Group ================================================
+// ======================================================================================
+
+// Key
+func (s *GroupObj) Key() string {
+	return s.key
+}
+
+// Value returns the details of the group ([]IDetails)
+func (s *GroupObj) Value() interface{} {
+	return s.value
+}
+
+// Details
+func (s *GroupObj) Details() []IDetails {
+	return s.value
+}
+
+// ======================================================================================
+// ============================== Secret ================================================
+// ======================================================================================
+
+// Key
+func (s *SecretObj) Key() string {
+	return s.key
+}
+
+// Value returns RedactedValue, the value of a secret is not kept
+func (s *SecretObj) Value() interface{} {
+	return RedactedValue
+}
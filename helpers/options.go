@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"io"
+)
+
+// Options of a logger, set by the Option functions passed to its constructor. Each logger applies the options it supports and ignores the others
+type Options struct {
+	// Level is the level of the logger, the default level of the logger if UnknownLevel
+	Level Level
+	// Writer is the output of the logger, the default output of the logger (os.Stderr) if nil
+	Writer io.Writer
+	// TimeFormat is the layout of the time of the entries (see time.Layout), the default layout of the logger if empty
+	TimeFormat string
+	// Encoding is the output format, e.g. TextFormat or JSONFormat, the default format of the logger if empty
+	Encoding string
+	// Color enables or disables the colors, the loggers color the terminals if nil
+	Color *bool
+	// Caller adds the call site of the entries, see SetCaller
+	Caller bool
+	// Fields are the details added to every entry, see ILogger.With
+	Fields []IDetails
+	// SpinnerCharset is the frames of the spinner of the icon logger
+	SpinnerCharset []string
+}
+
+// Option sets an option of a logger
+type Option func(o *Options)
+
+// ApplyOptions returns the options set by opts
+func ApplyOptions(opts ...Option) Options {
+	o := Options{Level: UnknownLevel}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithLevel sets the level of the logger
+func WithLevel(level Level) Option {
+	return func(o *Options) { o.Level = level }
+}
+
+// WithWriter sets the output of the logger
+func WithWriter(w io.Writer) Option {
+	return func(o *Options) { o.Writer = w }
+}
+
+// WithTimeFormat sets the layout of the time of the entries, e.g. time.RFC3339Nano. The text format of the pretty and icon loggers prints the time only if set
+func WithTimeFormat(layout string) Option {
+	return func(o *Options) { o.TimeFormat = layout }
+}
+
+// WithEncoding sets the output format: "text" or "json" for the pretty, icon and slog loggers, "json" or "console" for the zap logger. Unknown formats are ignored
+func WithEncoding(encoding string) Option {
+	return func(o *Options) { o.Encoding = encoding }
+}
+
+// WithColor enables or disables the colors of the pretty logger and of the console encoding of the zap logger
+func WithColor(enabled bool) Option {
+	return func(o *Options) { o.Color = &enabled }
+}
+
+// WithCaller adds the call site of the entries of the logger as "caller" and "function" details, as SetCaller does for all the loggers
+func WithCaller(enabled bool) Option {
+	return func(o *Options) { o.Caller = enabled }
+}
+
+// WithFields adds the details to every entry of the logger
+func WithFields(details ...IDetails) Option {
+	return func(o *Options) { o.Fields = append(o.Fields, details...) }
+}
+
+// WithSpinnerCharset sets the frames of the spinner of the icon logger, e.g. spinner.CharSets[14] of github.com/briandowns/spinner
+func WithSpinnerCharset(charset []string) Option {
+	return func(o *Options) { o.SpinnerCharset = charset }
+}
@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"io"
+	"os"
+)
+
+// SyncOutput commits the output of a logger to its storage if supported, e.g. files.
+// os.Stdout and os.Stderr are not synced, syncing them fails when they are terminals or pipes
+func SyncOutput(w io.Writer) error {
+	if isStd(w) {
+		return nil
+	}
+	if s, ok := w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// CloseOutput syncs and closes the output of a logger if supported, os.Stdout and os.Stderr are never closed
+func CloseOutput(w io.Writer) error {
+	if isStd(w) {
+		return nil
+	}
+	if c, ok := w.(io.Closer); ok {
+		// closing a file does not commit it to the disk
+		if err := SyncOutput(w); err != nil {
+			c.Close()
+			return err
+		}
+		return c.Close()
+	}
+	return SyncOutput(w)
+}
+
+func isStd(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (f == os.Stdout || f == os.Stderr)
+}
@@ -0,0 +1,259 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// RedactedValue replaces the redacted values
+const RedactedValue = "[REDACTED]"
+
+// Redactor returns the value logged instead of the value of a detail and true, or false to leave the detail as is
+type Redactor func(key string, value interface{}) (interface{}, bool)
+
+// DefaultRedactedKeys are the default key rules, the values of the details whose key ends with one of them are redacted, see AddRedactedKeys
+var DefaultRedactedKeys = []string{"password", "passwd", "token", "secret", "authorization", "apikey", "api_key", "credential", "credentials"}
+
+// pattern replaces the matches of re by replacement, see regexp.Regexp.ReplaceAllString
+type pattern struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// defaultPatterns match the secrets found in string values
+var defaultPatterns = []pattern{
+	// JWTs, e.g. service account tokens
+	{re: regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), replacement: RedactedValue},
+	// AWS access key IDs
+	{re: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA|AGPA|AIDA|AIPA|ANPA|ANVA|AROA)[A-Z0-9]{16}\b`), replacement: RedactedValue},
+	// AWS secret access keys
+	{re: regexp.MustCompile(`(?i)(aws_?secret_?access_?key["']?\s*[:=]\s*["']?)[A-Za-z0-9/+=]{40}`), replacement: "${1}" + RedactedValue},
+	// bearer and basic authorization headers
+	{re: regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`), replacement: "$1 " + RedactedValue},
+	// key/value pairs of secrets, e.g. in kubeconfig and registry configuration fragments
+	{re: regexp.MustCompile(`(?i)\b((?:password|passwd|token|secret|client-key-data|auth)["']?\s*[:=]\s*["']?)[^\s"',;{}\[\]]+`), replacement: "${1}" + RedactedValue},
+}
+
+// redaction rules, replaced as a whole when a rule is added so the loggers read them without locking
+type redaction struct {
+	keys      []string // lower case segments of the key rules, joined
+	patterns  []pattern
+	redactors []Redactor
+}
+
+var (
+	redactionMutex sync.Mutex // serializes the updates of the rules
+	redactionRules atomic.Pointer[redaction]
+)
+
+func init() {
+	ResetRedaction()
+}
+
+// AddRedactedKeys adds key rules, the values of the details whose key ends with the segments of one of them are redacted.
+// The keys are split into case insensitive segments at '_', '-', '.' and the camelCase words,
+// e.g. the "token" rule redacts "token", "accessToken" and "x-auth-token" but not "tokenCount" or "tokenizer".
+// Numbers and booleans are not redacted by the key rules
+func AddRedactedKeys(keys ...string) {
+	updateRedaction(func(r *redaction) {
+		for _, k := range keys {
+			r.keys = append(r.keys, strings.Join(keySegments(k), ""))
+		}
+	})
+}
+
+// AddRedactedPattern adds a value rule, the matches of re in the string values of the details are replaced by RedactedValue
+func AddRedactedPattern(re *regexp.Regexp) {
+	updateRedaction(func(r *redaction) {
+		r.patterns = append(r.patterns, pattern{re: re, replacement: RedactedValue})
+	})
+}
+
+// RegisterRedactor registers a custom redactor, called with each detail that is not redacted by the key rules.
+// Groups are redacted detail by detail
+func RegisterRedactor(redactor Redactor) {
+	updateRedaction(func(r *redaction) {
+		r.redactors = append(r.redactors, redactor)
+	})
+}
+
+// ResetRedaction restores the default rules, removing the custom ones
+func ResetRedaction() {
+	redactionMutex.Lock()
+	defer redactionMutex.Unlock()
+	r := &redaction{patterns: defaultPatterns}
+	for _, k := range DefaultRedactedKeys {
+		r.keys = append(r.keys, strings.Join(keySegments(k), ""))
+	}
+	redactionRules.Store(r)
+}
+
+func updateRedaction(update func(r *redaction)) {
+	redactionMutex.Lock()
+	defer redactionMutex.Unlock()
+	current := redactionRules.Load()
+	r := &redaction{
+		keys:      append([]string{}, current.keys...),
+		patterns:  append([]pattern{}, current.patterns...),
+		redactors: append([]Redactor{}, current.redactors...),
+	}
+	update(r)
+	redactionRules.Store(r)
+}
+
+// Redact returns the details with the secrets redacted, the loggers call it before rendering the details.
+// The slice is returned as is if there is nothing to redact
+func Redact(details []IDetails) []IDetails {
+	redacted, _ := redactionRules.Load().redact(details)
+	return redacted
+}
+
+// redact returns the redacted details and true, or the details and false if they have nothing to redact
+func (r *redaction) redact(details []IDetails) ([]IDetails, bool) {
+	var redacted []IDetails // allocated on the first redacted detail
+	for i := range details {
+		d, ok := r.redactDetail(details[i])
+		if ok && redacted == nil {
+			redacted = append(make([]IDetails, 0, len(details)), details[:i]...)
+		}
+		if redacted != nil {
+			redacted = append(redacted, d)
+		}
+	}
+	if redacted == nil {
+		return details, false
+	}
+	return redacted, true
+}
+
+// redactDetail returns the redacted detail and true, or the detail and false if it has nothing to redact
+func (r *redaction) redactDetail(detail IDetails) (IDetails, bool) {
+	if _, ok := detail.(*SecretObj); ok {
+		return detail, false
+	}
+	key := detail.Key()
+	if r.matchKey(key) && !isNumberOrBool(detail.Value()) {
+		return Secret(key, ""), true
+	}
+	if group, ok := detail.(*GroupObj); ok {
+		if redacted, ok := r.redact(group.Details()); ok {
+			return Group(key, redacted...), true
+		}
+		return detail, false
+	}
+	value := detail.Value()
+	for _, redactor := range r.redactors {
+		if v, ok := redactor(key, value); ok {
+			return Interface(key, v), true
+		}
+	}
+	switch v := value.(type) {
+	case nil, bool, int, int64, uint64, float64, time.Duration, time.Time, Bytes, []int:
+		return detail, false
+	case string:
+		if s, ok := r.redactString(v); ok {
+			return String(key, s), true
+		}
+		return detail, false
+	case []string:
+		var redacted []string
+		for i := range v {
+			s, ok := r.redactString(v[i])
+			if ok && redacted == nil {
+				redacted = append([]string{}, v...)
+			}
+			if redacted != nil {
+				redacted[i] = s
+			}
+		}
+		if redacted == nil {
+			return detail, false
+		}
+		return Strings(key, redacted), true
+	case []byte:
+		if s, ok := r.redactString(string(v)); ok {
+			return String(key, s), true
+		}
+		return detail, false
+	case error:
+		if s, ok := r.redactString(v.Error()); ok {
+			return String(key, s), true
+		}
+		return detail, false
+	}
+	// Stringers and other values are redacted as formatted by the loggers
+	if s, ok := r.redactString(fmt.Sprintf("%v", value)); ok {
+		return String(key, s), true
+	}
+	return detail, false
+}
+
+// matchKey returns true if the key ends with the segments of a key rule
+func (r *redaction) matchKey(key string) bool {
+	segments := keySegments(key)
+	suffix := ""
+	for i := len(segments) - 1; i >= 0; i-- {
+		suffix = segments[i] + suffix
+		for _, k := range r.keys {
+			if suffix == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keySegments returns the lower case segments of the key, split at the characters other than letters and digits and at the camelCase words,
+// e.g. "registryAPIToken" returns "registry", "api" and "token"
+func keySegments(key string) []string {
+	var segments []string
+	runes := []rune(key)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				segments = append(segments, strings.ToLower(string(runes[start:i])))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			// "aB" and the last upper case letter of an acronym followed by a word, "ABc"
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || i+1 < len(runes) && unicode.IsUpper(previous) && unicode.IsLower(runes[i+1]) {
+				segments = append(segments, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		segments = append(segments, strings.ToLower(string(runes[start:])))
+	}
+	return segments
+}
+
+// isNumberOrBool returns true for the numbers and the booleans, which are not secrets, e.g. a token count
+func isNumberOrBool(value interface{}) bool {
+	switch value.(type) {
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Duration:
+		return true
+	}
+	return false
+}
+
+// redactString replaces the matches of the patterns, it returns false if there is none
+func (r *redaction) redactString(s string) (string, bool) {
+	redacted := s
+	for _, p := range r.patterns {
+		redacted = p.re.ReplaceAllString(redacted, p.replacement)
+	}
+	return redacted, redacted != s
+}
@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of the trace details, see TraceDetails
+const (
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceFlagsKey = "trace_flags"
+)
+
+// TraceDetails returns the trace ID, span ID and trace flags of the span of ctx, or nil if ctx has no valid span.
+// The loggers add them to their entries in Ctx to correlate logs with traces
+func TraceDetails(ctx context.Context) []IDetails {
+	if ctx == nil {
+		return nil
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []IDetails{
+		String(TraceIDKey, sc.TraceID().String()),
+		String(SpanIDKey, sc.SpanID().String()),
+		String(TraceFlagsKey, sc.TraceFlags().String()),
+	}
+}
@@ -0,0 +1,47 @@
+package hooklogger
+
+import (
+	"strings"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+// AddDetails returns a hook adding the details to every entry, e.g. the name of the pod
+func AddDetails(details ...helpers.IDetails) Hook {
+	return func(e *helpers.Entry) bool {
+		*e = e.WithDetails(details...)
+		return true
+	}
+}
+
+// DropIf returns a hook dropping the entries matching drop
+func DropIf(drop func(e *helpers.Entry) bool) Hook {
+	return func(e *helpers.Entry) bool {
+		return !drop(e)
+	}
+}
+
+// DropMessages returns a hook dropping the entries whose message contains one of the substrings
+func DropMessages(substrings ...string) Hook {
+	return DropIf(func(e *helpers.Entry) bool {
+		for _, s := range substrings {
+			if strings.Contains(e.Message, s) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Forward returns a hook calling forward with the entries of level or above, e.g. to report the errors to another system.
+// The details of the forwarded entry are prepared as by the loggers (see helpers.PrepareDetails), the entry is logged as is
+func Forward(level helpers.Level, forward func(e helpers.Entry)) Hook {
+	return func(e *helpers.Entry) bool {
+		if !e.Level.Skip(level) {
+			forwarded := *e
+			forwarded.Details = helpers.PrepareDetails(e.Level, e.Details, false)
+			forward(forwarded)
+		}
+		return true
+	}
+}
@@ -0,0 +1,184 @@
+package hooklogger
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "hooks"
+
+// Hook is called with each entry before it is logged. It can modify the entry, e.g. add details, and returns false to drop it
+type Hook func(entry *helpers.Entry) bool
+
+// HookLogger passes the entries through a chain of hooks before logging them with the target logger.
+// The hooks run in their order and the chain stops at the first hook dropping the entry. Fatal entries cannot be dropped
+type HookLogger struct {
+	target helpers.ILogger
+	chain  *chain
+	ctx    context.Context
+	fields []helpers.IDetails // details added to every entry, see With
+	name   string             // component name, see Named
+}
+
+// chain of hooks, shared by a logger and its children
+type chain struct {
+	mutex sync.RWMutex
+	hooks []Hook
+}
+
+var _ helpers.ILogger = (*HookLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*HookLogger)(nil)
+var _ helpers.CallerLogger = (*HookLogger)(nil)
+
+// NewHookLogger returns a logger passing the entries through the hooks before logging them with target
+func NewHookLogger(target helpers.ILogger, hooks ...Hook) *HookLogger {
+	return &HookLogger{target: target, chain: &chain{hooks: append([]Hook{}, hooks...)}, ctx: context.Background()}
+}
+
+// Target returns the logger the entries are logged with
+func (hl *HookLogger) Target() helpers.ILogger { return hl.target }
+
+// AddHook appends hooks to the chain of the logger, its parents and its children
+func (hl *HookLogger) AddHook(hooks ...Hook) {
+	hl.chain.mutex.Lock()
+	defer hl.chain.mutex.Unlock()
+	hl.chain.hooks = append(hl.chain.hooks, hooks...)
+}
+
+func (hl *HookLogger) LoggerName() string          { return LoggerName }
+func (hl *HookLogger) GetLevel() string            { return hl.target.GetLevel() }
+func (hl *HookLogger) SetLevel(level string) error { return hl.target.SetLevel(level) }
+func (hl *HookLogger) SetWriter(w *os.File)        { hl.target.SetWriter(w) }
+func (hl *HookLogger) GetWriter() *os.File         { return hl.target.GetWriter() }
+func (hl *HookLogger) SetOutput(w io.Writer)       { hl.target.SetOutput(w) }
+func (hl *HookLogger) GetOutput() io.Writer        { return hl.target.GetOutput() }
+func (hl *HookLogger) Sync() error                 { return hl.target.Sync() }
+func (hl *HookLogger) Close() error                { return hl.target.Close() }
+func (hl *HookLogger) Caller() bool                { return helpers.LogsCaller(hl.target) }
+
+// Ctx returns a hook logger of the child of the target, the context is passed to the hooks as Entry.Ctx
+func (hl *HookLogger) Ctx(ctx context.Context) helpers.ILogger {
+	clone := *hl
+	clone.target = hl.target.Ctx(ctx)
+	clone.ctx = ctx
+	return &clone
+}
+
+// With returns a child logger adding the details to every entry, before the hooks so they can modify them
+func (hl *HookLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return hl
+	}
+	clone := *hl
+	clone.fields = append(append([]helpers.IDetails{}, hl.fields...), details...)
+	return &clone
+}
+
+// Named returns a hook logger of the child of the target, the name is passed to the hooks as Entry.Name
+func (hl *HookLogger) Named(name string) helpers.ILogger {
+	clone := *hl
+	clone.target = hl.target.Named(name)
+	clone.name = helpers.JoinNames(hl.name, name)
+	return &clone
+}
+
+// Fatal passes the entry through the hooks and logs it with the target even if a hook drops it
+func (hl *HookLogger) Fatal(msg string, details ...helpers.IDetails) {
+	e := hl.entry("", helpers.FatalLevel, msg, details)
+	hl.runHooks(&e)
+	hl.target.Fatal(e.Message, e.Details...)
+}
+
+// PrintFatal passes the entry through the hooks and logs it with the target without calling the fatal handler, see helpers.FatalPrinter
+func (hl *HookLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	e := hl.entry("", helpers.FatalLevel, msg, details)
+	hl.runHooks(&e)
+	helpers.PrintFatal(hl.target, e.Message, e.Details...)
+}
+
+func (hl *HookLogger) Error(msg string, details ...helpers.IDetails) {
+	hl.log("", helpers.ErrorLevel, msg, details)
+}
+func (hl *HookLogger) Warning(msg string, details ...helpers.IDetails) {
+	hl.log("", helpers.WarningLevel, msg, details)
+}
+func (hl *HookLogger) Success(msg string, details ...helpers.IDetails) {
+	hl.log("", helpers.SuccessLevel, msg, details)
+}
+func (hl *HookLogger) Info(msg string, details ...helpers.IDetails) {
+	hl.log("", helpers.InfoLevel, msg, details)
+}
+func (hl *HookLogger) Debug(msg string, details ...helpers.IDetails) {
+	hl.log("", helpers.DebugLevel, msg, details)
+}
+func (hl *HookLogger) Start(msg string, details ...helpers.IDetails) {
+	hl.log(helpers.StartEvent, helpers.InfoLevel, msg, details)
+}
+func (hl *HookLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	hl.log(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details)
+}
+func (hl *HookLogger) StopError(msg string, details ...helpers.IDetails) {
+	hl.log(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details)
+}
+
+func (hl *HookLogger) entry(event string, level helpers.Level, msg string, details []helpers.IDetails) helpers.Entry {
+	e := helpers.Entry{Level: level, Event: event, Message: msg, Details: details, Time: time.Now(), Ctx: hl.ctx, Name: hl.name}
+	if len(hl.fields) > 0 {
+		e.Details = append(append(make([]helpers.IDetails, 0, len(hl.fields)+len(details)), hl.fields...), details...)
+	}
+	return e
+}
+
+// log passes the entry through the hooks and logs it with the target, the entries filtered by the target level skip the hooks
+func (hl *HookLogger) log(event string, level helpers.Level, msg string, details []helpers.IDetails) {
+	if targetLevel := helpers.ToLevel(hl.target.GetLevel()); targetLevel != helpers.UnknownLevel && level.Skip(targetLevel) {
+		return
+	}
+	e := hl.entry(event, level, msg, details)
+	if !hl.runHooks(&e) {
+		return
+	}
+
+	switch e.Event {
+	case helpers.StartEvent:
+		hl.target.Start(e.Message, e.Details...)
+		return
+	case helpers.StopSuccessEvent:
+		hl.target.StopSuccess(e.Message, e.Details...)
+		return
+	case helpers.StopErrorEvent:
+		hl.target.StopError(e.Message, e.Details...)
+		return
+	}
+	switch e.Level {
+	case helpers.DebugLevel:
+		hl.target.Debug(e.Message, e.Details...)
+	case helpers.InfoLevel:
+		hl.target.Info(e.Message, e.Details...)
+	case helpers.SuccessLevel:
+		hl.target.Success(e.Message, e.Details...)
+	case helpers.WarningLevel:
+		hl.target.Warning(e.Message, e.Details...)
+	default:
+		// a hook cannot make an entry fatal
+		hl.target.Error(e.Message, e.Details...)
+	}
+}
+
+// runHooks returns false if a hook dropped the entry
+func (hl *HookLogger) runHooks(e *helpers.Entry) bool {
+	hl.chain.mutex.RLock()
+	hooks := hl.chain.hooks
+	hl.chain.mutex.RUnlock()
+	for _, hook := range hooks {
+		if !hook(e) {
+			return false
+		}
+	}
+	return true
+}
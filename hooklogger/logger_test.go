@@ -0,0 +1,96 @@
+package hooklogger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTarget(t *testing.T) (*prettylogger.PrettyLogger, *bytes.Buffer) {
+	prettylogger.DisableColor(true)
+	t.Cleanup(func() { prettylogger.EnableColor(true) })
+
+	b := &bytes.Buffer{}
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(b)
+	return target, b
+}
+
+type ctxKey struct{}
+
+func TestHookLogger(t *testing.T) {
+	target, b := newTarget(t)
+
+	var forwarded []helpers.Entry
+	logger := NewHookLogger(target,
+		AddDetails(helpers.String("pod", "kubescape-0")),
+		DropMessages("health check"),
+		Forward(helpers.WarningLevel, func(e helpers.Entry) { forwarded = append(forwarded, e) }),
+	)
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234")).Ctx(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+	child.Info("scanning", helpers.Int("resources", 3))
+	child.Info("health check passed")
+	child.Debug("filtered")
+	child.Error("failed", helpers.String("token", "abc"))
+	child.Start("downloading")
+
+	assert.Equal(t, `[info] [scanner] scanning. scanID: 1234; resources: 3; pod: kubescape-0
+[error] [scanner] failed. scanID: 1234; token: [REDACTED]; pod: kubescape-0
+[info] [scanner] downloading. scanID: 1234; pod: kubescape-0
+`, b.String())
+
+	assert.Len(t, forwarded, 1)
+	assert.Equal(t, helpers.ErrorLevel, forwarded[0].Level)
+	assert.Equal(t, "failed", forwarded[0].Message)
+	assert.Equal(t, "scanner", forwarded[0].Name)
+	assert.Equal(t, "value", forwarded[0].Ctx.Value(ctxKey{}))
+	assert.False(t, forwarded[0].Time.IsZero())
+	assert.Equal(t, helpers.RedactedValue, forwarded[0].Details[1].Value())
+}
+
+func TestHookLoggerModify(t *testing.T) {
+	target, b := newTarget(t)
+
+	logger := NewHookLogger(target)
+	child := logger.Named("scanner")
+	// the hooks added to a logger apply to its children
+	logger.AddHook(func(e *helpers.Entry) bool {
+		if strings.HasPrefix(e.Message, "deprecated") {
+			e.Level = helpers.WarningLevel
+		}
+		if e.Level == helpers.ErrorLevel {
+			e.Level = helpers.FatalLevel
+		}
+		e.Message = strings.ToUpper(e.Message)
+		return true
+	})
+
+	child.Info("deprecated field")
+	child.Error("failed")
+	child.StopSuccess("done")
+
+	// the level of an entry cannot be raised to fatal
+	assert.Equal(t, `[warning] [scanner] DEPRECATED FIELD
+[error] [scanner] FAILED
+[success] [scanner] DONE
+`, b.String())
+}
+
+func TestHookLoggerFatal(t *testing.T) {
+	target, b := newTarget(t)
+	recorder, restore := helpers.SetFatalTestMode()
+	defer restore()
+
+	logger := NewHookLogger(target, DropIf(func(e *helpers.Entry) bool { return true }))
+	logger.Info("dropped")
+	logger.Fatal("cannot be dropped")
+
+	assert.Equal(t, "[fatal] cannot be dropped\n", b.String())
+	assert.Equal(t, []string{"cannot be dropped"}, recorder.Messages())
+}
@@ -3,8 +3,10 @@ package iconlogger
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
+	"time"
 
 	spinnerpkg "github.com/briandowns/spinner"
 	"github.com/kubescape/go-logger/helpers"
@@ -13,40 +15,199 @@ import (
 const LoggerName string = "icon"
 
 type IconLogger struct {
-	writer  *os.File
-	level   helpers.Level
-	spinner *spinnerpkg.Spinner
-	mutex   sync.Mutex
+	writer         io.Writer
+	level          helpers.Level
+	format         string // helpers.TextFormat or helpers.JSONFormat, empty is text
+	timeFormat     string // layout of the time, the text format prints no time if empty
+	caller         bool   // adds the call site, see helpers.WithCaller
+	spinner        *spinnerpkg.Spinner
+	spinnerCharset []string // frames of the spinner, spinner.CharSets[70] if nil
+	mutex          sync.Mutex
+
+	parent *IconLogger        // root logger of a child created by With or Named, owns the level, the writer and the spinner
+	fields []helpers.IDetails // details added to every entry
+	trace  []helpers.IDetails // trace details of the span of the context, replaced by Ctx
+	name   string             // component name, see Named
 }
 
 var _ helpers.ILogger = (*IconLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*IconLogger)(nil)
+var _ helpers.CallerLogger = (*IconLogger)(nil)
 
-func NewIconLogger() *IconLogger {
+// NewIconLogger returns a logger printing to stderr at the info level, unless set otherwise by the options
+func NewIconLogger(opts ...helpers.Option) *IconLogger {
+	o := helpers.ApplyOptions(opts...)
+	il := &IconLogger{
+		writer:         os.Stderr, // default to stderr
+		level:          helpers.InfoLevel,
+		format:         helpers.TextFormat,
+		timeFormat:     o.TimeFormat,
+		caller:         o.Caller,
+		spinner:        nil,
+		spinnerCharset: o.SpinnerCharset,
+		mutex:          sync.Mutex{},
+		fields:         o.Fields,
+	}
+	if o.Writer != nil {
+		il.writer = o.Writer
+	}
+	if o.Level != helpers.UnknownLevel {
+		il.level = o.Level
+	}
+	if o.Encoding != "" {
+		if format, err := helpers.ToFormat(o.Encoding); err == nil {
+			il.format = format
+		}
+	}
+	return il
+}
 
-	return &IconLogger{
-		writer:  os.Stderr, // default to stderr
-		level:   helpers.InfoLevel,
-		spinner: nil,
-		mutex:   sync.Mutex{},
+func (il *IconLogger) GetLevel() string     { return il.effectiveLevel().String() }
+func (il *IconLogger) SetWriter(w *os.File) { il.SetOutput(w) }
+func (il *IconLogger) LoggerName() string   { return LoggerName }
+
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (il *IconLogger) Caller() bool { return il.root().caller }
+
+func (il *IconLogger) GetWriter() *os.File {
+	f, _ := il.GetOutput().(*os.File)
+	return f
+}
+
+func (il *IconLogger) SetOutput(w io.Writer) {
+	root := il.root()
+	root.mutex.Lock()
+	root.writer = w
+	root.mutex.Unlock()
+}
+
+func (il *IconLogger) GetOutput() io.Writer {
+	root := il.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return root.writer
+}
+
+// SetFormat sets the output format, "text" (default) or "json". The JSON format does not display the spinner, Start, StopSuccess and StopError are logged with their event instead
+func (il *IconLogger) SetFormat(format string) error {
+	f, err := helpers.ToFormat(format)
+	if err != nil {
+		return err
 	}
+	root := il.root()
+	root.mutex.Lock()
+	root.format = f
+	root.mutex.Unlock()
+	return nil
+}
+
+func (il *IconLogger) isJSON() bool {
+	root := il.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return root.format == helpers.JSONFormat
 }
 
-func (il *IconLogger) GetLevel() string                      { return il.level.String() }
-func (il *IconLogger) SetWriter(w *os.File)                  { il.writer = w }
-func (il *IconLogger) GetWriter() *os.File                   { return il.writer }
-func (il *IconLogger) Ctx(_ context.Context) helpers.ILogger { return il }
-func (il *IconLogger) LoggerName() string                    { return LoggerName }
+// Sync commits the output to its storage if supported, e.g. files
+func (il *IconLogger) Sync() error {
+	root := il.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.SyncOutput(root.writer)
+}
+
+// Close stops the spinner and closes the output if supported, os.Stdout and os.Stderr are not closed
+func (il *IconLogger) Close() error {
+	root := il.root()
+	root.StopSpinner("")
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.CloseOutput(root.writer)
+}
 
 func (il *IconLogger) SetLevel(level string) error {
-	il.level = helpers.ToLevel(level)
-	if il.level == helpers.UnknownLevel {
+	root := il.root()
+	root.level = helpers.ToLevel(level)
+	if root.level == helpers.UnknownLevel {
 		return fmt.Errorf("level '%s' unknown", level)
 	}
 	return nil
 }
+
+// Ctx returns a child logger adding the trace_id, span_id and trace_flags of the span of ctx to every entry, replacing those of a previous Ctx
+func (il *IconLogger) Ctx(ctx context.Context) helpers.ILogger {
+	trace := helpers.TraceDetails(ctx)
+	if trace == nil && il.trace == nil {
+		return il
+	}
+	return &IconLogger{
+		parent: il.root(),
+		fields: il.fields,
+		trace:  trace,
+		name:   il.name,
+	}
+}
+
+// With returns a child logger printing the details with every entry. The child shares the level, the writer and the spinner of its parent
+func (il *IconLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return il
+	}
+	return &IconLogger{
+		parent: il.root(),
+		fields: append(append([]helpers.IDetails{}, il.fields...), details...),
+		trace:  il.trace,
+		name:   il.name,
+	}
+}
+
+// Named returns a child logger of the component name. The component name is printed before the message and its level can be set with helpers.SetComponentLevel
+func (il *IconLogger) Named(name string) helpers.ILogger {
+	return &IconLogger{
+		parent: il.root(),
+		fields: il.fields,
+		trace:  il.trace,
+		name:   helpers.JoinNames(il.name, name),
+	}
+}
+
+func (il *IconLogger) root() *IconLogger {
+	if il.parent != nil {
+		return il.parent
+	}
+	return il
+}
+
+func (il *IconLogger) effectiveLevel() helpers.Level {
+	return helpers.EffectiveLevel(il.name, il.root().level)
+}
+
+// generateMessage adds the component name and the details to the message
+func (il *IconLogger) generateMessage(level helpers.Level, msg string, details []helpers.IDetails) string {
+	if il.name != "" {
+		msg = fmt.Sprintf("[%s] %s", il.name, msg)
+	}
+	return generateMessage(msg, il.withFields(level, details))
+}
+
+// withFields prepends the details and the trace details of the logger to the entry details and prepares them for rendering (see helpers.PrepareDetails)
+func (il *IconLogger) withFields(level helpers.Level, details []helpers.IDetails) []helpers.IDetails {
+	if len(il.fields) == 0 && len(il.trace) == 0 {
+		return helpers.PrepareDetails(level, details, il.root().caller)
+	}
+	all := make([]helpers.IDetails, 0, len(il.fields)+len(il.trace)+len(details))
+	return helpers.PrepareDetails(level, append(append(append(all, il.fields...), il.trace...), details...), il.root().caller)
+}
 func (il *IconLogger) Fatal(msg string, details ...helpers.IDetails) {
+	il.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (il *IconLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	il.root().StopSpinner("")
 	il.print(helpers.FatalLevel, msg, details...)
-	os.Exit(1)
+	il.Sync()
 }
 func (il *IconLogger) Error(msg string, details ...helpers.IDetails) {
 	il.print(helpers.ErrorLevel, msg, details...)
@@ -64,30 +225,72 @@ func (il *IconLogger) Success(msg string, details ...helpers.IDetails) {
 	il.print(helpers.SuccessLevel, msg, details...)
 }
 func (il *IconLogger) Start(msg string, details ...helpers.IDetails) {
-	il.StartSpinner(il.writer, generateMessage(msg, details))
+	if il.isJSON() {
+		il.printJSON(helpers.StartEvent, helpers.InfoLevel, msg, details)
+		return
+	}
+	root := il.root()
+	root.StartSpinner(root.GetOutput(), il.generateMessage(helpers.InfoLevel, msg, details))
 }
 func (il *IconLogger) StopSuccess(msg string, details ...helpers.IDetails) {
-	il.StopSpinner(getSymbol("success") + generateMessage(msg, details) + "\n")
+	if il.isJSON() {
+		il.printJSON(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details)
+		return
+	}
+	il.root().StopSpinner(getSymbol("success") + il.generateMessage(helpers.SuccessLevel, msg, details) + "\n")
 }
 func (il *IconLogger) StopError(msg string, details ...helpers.IDetails) {
-	il.StopSpinner(getSymbol("error") + generateMessage(msg, details) + "\n")
+	if il.isJSON() {
+		il.printJSON(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details)
+		return
+	}
+	il.root().StopSpinner(getSymbol("error") + il.generateMessage(helpers.ErrorLevel, msg, details) + "\n")
 }
 
 func (il *IconLogger) print(level helpers.Level, msg string, details ...helpers.IDetails) {
-	il.PauseSpinner()
-	if !level.Skip(il.level) {
-		il.mutex.Lock()
-		fmt.Fprintf(il.writer, "%s", getSymbol(level.String()))
-		fmt.Fprintf(il.writer, fmt.Sprintf("%s\n", generateMessage(msg, details)))
-		il.mutex.Unlock()
+	if il.isJSON() {
+		il.printJSON("", level, msg, details)
+		return
+	}
+	root := il.root()
+	root.PauseSpinner()
+	if !level.Skip(il.effectiveLevel()) {
+		root.mutex.Lock()
+		if root.writer != nil {
+			if root.timeFormat != "" {
+				fmt.Fprintf(root.writer, "%s", time.Now().Format(root.timeFormat))
+			}
+			fmt.Fprintf(root.writer, "%s", getSymbol(level.String()))
+			fmt.Fprintf(root.writer, fmt.Sprintf("%s\n", il.generateMessage(level, msg, details)))
+		}
+		root.mutex.Unlock()
+	}
+	root.ResumeSpinner()
+}
+
+// printJSON prints the entry as a JSON line if its level is enabled, including the start and stop events like the pretty logger
+func (il *IconLogger) printJSON(event string, level helpers.Level, msg string, details []helpers.IDetails) {
+	if level.Skip(il.effectiveLevel()) {
+		return
+	}
+	root := il.root()
+	layout := root.timeFormat
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	line := helpers.JSONLineWithTimeFormat(layout, level, time.Now(), il.name, event, msg, il.withFields(level, details))
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	if root.writer != nil {
+		root.writer.Write(line)
 	}
-	il.ResumeSpinner()
 }
 
 func detailsToString(details []helpers.IDetails) string {
+	details = helpers.Flatten(details)
 	s := ""
 	for i := range details {
-		s += fmt.Sprintf("%s: %v", details[i].Key(), details[i].Value())
+		s += fmt.Sprintf("%s: %s", details[i].Key(), helpers.FormatValue(details[i].Value()))
 		if i < len(details)-1 {
 			s += "; "
 		}
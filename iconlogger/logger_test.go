@@ -1,8 +1,12 @@
 package iconlogger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
@@ -152,3 +156,97 @@ func TestIconLoggerLoggerName(t *testing.T) {
 	logger := &IconLogger{}
 	assert.Equal(t, LoggerName, logger.LoggerName())
 }
+
+func TestIconLoggerWith(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "icon")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	logger := NewIconLogger()
+	logger.SetWriter(f)
+	child := logger.With(helpers.String("scanID", "1234"))
+	child.Warning("scanning", helpers.Int("resources", 3))
+	child.Debug("not printed")
+
+	// the child follows the level of its parent
+	assert.NoError(t, logger.SetLevel("debug"))
+	assert.Equal(t, "debug", child.GetLevel())
+	child.With(helpers.String("namespace", "default")).Debug("printed")
+
+	b, err := os.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, " ⚠️   scanning. scanID: 1234; resources: 3\n 🐞  printed. scanID: 1234; namespace: default\n", string(b))
+}
+
+func TestIconLoggerSetOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewIconLogger()
+	logger.SetOutput(b)
+	assert.Equal(t, b, logger.GetOutput())
+	assert.Nil(t, logger.GetWriter())
+
+	logger.With(helpers.String("key", "value")).Info("to buffer")
+	assert.Equal(t, " ℹ️   to buffer. key: value\n", b.String())
+}
+
+func TestIconLoggerSpinnerWriter(t *testing.T) {
+	// the spinner is not started on the writers other than files, the start and stop lines are printed instead
+	b := &bytes.Buffer{}
+	logger := NewIconLogger(helpers.WithWriter(b))
+	logger.Start("scanning")
+	assert.Nil(t, logger.spinner)
+	logger.StopSuccess("done")
+	assert.Equal(t, " ℹ️   scanning\n ✅  done\n", b.String())
+}
+
+func TestIconLoggerJSONFormat(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewIconLogger()
+	logger.SetOutput(b)
+	assert.NoError(t, logger.SetFormat("json"))
+
+	logger.Start("scanning", helpers.Int("resources", 3))
+	logger.Warning("careful")
+	logger.StopError("failed", helpers.Error(fmt.Errorf("timeout")))
+	assert.Nil(t, logger.spinner)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Len(t, lines, 3)
+	for i, expected := range []map[string]interface{}{
+		{"level": "info", "event": "start", "msg": "scanning", "resources": float64(3)},
+		{"level": "warning", "msg": "careful"},
+		{"level": "error", "event": "stop_error", "msg": "failed", "error": "timeout"},
+	} {
+		entry := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+		delete(entry, "time")
+		assert.Equal(t, expected, entry)
+	}
+
+	// the events are filtered by the level, as the other entries
+	b.Reset()
+	assert.NoError(t, logger.SetLevel("warning"))
+	logger.Start("scanning")
+	logger.StopSuccess("done")
+	logger.StopError("failed")
+	assert.Equal(t, 1, strings.Count(b.String(), "\n"))
+	assert.Contains(t, b.String(), `"event":"stop_error"`)
+}
+
+func TestNewIconLoggerOptions(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewIconLogger(
+		helpers.WithWriter(b),
+		helpers.WithLevel(helpers.DebugLevel),
+		helpers.WithEncoding(helpers.JSONFormat),
+		helpers.WithFields(helpers.String("pod", "kubescape-0")),
+		helpers.WithSpinnerCharset([]string{".", "o", "O"}),
+	)
+	assert.Equal(t, []string{".", "o", "O"}, logger.spinnerCharset)
+	logger.Debug("scanning")
+
+	entry := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &entry))
+	delete(entry, "time")
+	assert.Equal(t, map[string]interface{}{"level": "debug", "msg": "scanning", "pod": "kubescape-0"}, entry)
+}
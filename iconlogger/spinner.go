@@ -1,6 +1,8 @@
 package iconlogger
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -8,15 +10,27 @@ import (
 	"github.com/mattn/go-isatty"
 )
 
-func (il *IconLogger) StartSpinner(w *os.File, message string) {
+func (il *IconLogger) StartSpinner(w io.Writer, message string) {
 	il.mutex.Lock()
 	defer il.mutex.Unlock()
 
 	if il.spinner != nil && il.spinner.Active() {
 		return
 	}
+	f, ok := w.(*os.File)
+	if !ok {
+		// the spinner would animate into the buffers, pipes and wrapped files, print the line instead
+		if w != nil {
+			fmt.Fprintf(w, "%s%s\n", getSymbol("info"), message)
+		}
+		return
+	}
 	if isSupported() {
-		il.spinner = spinnerpkg.New(spinnerpkg.CharSets[70], 100*time.Millisecond, spinnerpkg.WithWriterFile(w)) // Build our new spinner
+		charset := il.spinnerCharset
+		if charset == nil {
+			charset = spinnerpkg.CharSets[70]
+		}
+		il.spinner = spinnerpkg.New(charset, 100*time.Millisecond, spinnerpkg.WithWriterFile(f)) // Build our new spinner
 		il.spinner.Prefix = " "
 		il.spinner.Suffix = " " + message
 		il.spinner.Start()
@@ -28,6 +42,10 @@ func (il *IconLogger) StopSpinner(message string) {
 	defer il.mutex.Unlock()
 
 	if il.spinner == nil || !il.spinner.Active() {
+		// the lines of the writers without spinner
+		if _, ok := il.writer.(*os.File); !ok && il.writer != nil && message != "" {
+			fmt.Fprint(il.writer, message)
+		}
 		return
 	}
 	il.spinner.FinalMSG = message
@@ -0,0 +1,272 @@
+package logfmtlogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "logfmt"
+
+// LogfmtLogger prints key=value lines, e.g. time=2024-01-02T03:04:05Z level=info msg="scan started" scanID=1234
+type LogfmtLogger struct {
+	writer     io.Writer
+	level      helpers.Level
+	timeFormat string // layout of the time, time.RFC3339 if empty
+	caller     bool   // adds the call site, see helpers.WithCaller
+	mutex      sync.Mutex
+
+	parent *LogfmtLogger      // root logger of a child created by With or Named, owns the level and the writer
+	fields []helpers.IDetails // details added to every entry
+	trace  []helpers.IDetails // trace details of the span of the context, replaced by Ctx
+	name   string             // component name, see Named
+}
+
+var _ helpers.ILogger = (*LogfmtLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*LogfmtLogger)(nil)
+var _ helpers.CallerLogger = (*LogfmtLogger)(nil)
+
+// NewLogfmtLogger returns a logger printing to stderr at the info level, unless set otherwise by the options
+func NewLogfmtLogger(opts ...helpers.Option) *LogfmtLogger {
+	o := helpers.ApplyOptions(opts...)
+	ll := &LogfmtLogger{
+		writer:     os.Stderr, // default to stderr
+		level:      helpers.InfoLevel,
+		timeFormat: o.TimeFormat,
+		caller:     o.Caller,
+		mutex:      sync.Mutex{},
+		fields:     o.Fields,
+	}
+	if o.Writer != nil {
+		ll.writer = o.Writer
+	}
+	if o.Level != helpers.UnknownLevel {
+		ll.level = o.Level
+	}
+	return ll
+}
+
+func (ll *LogfmtLogger) GetLevel() string     { return ll.effectiveLevel().String() }
+func (ll *LogfmtLogger) SetWriter(w *os.File) { ll.SetOutput(w) }
+func (ll *LogfmtLogger) LoggerName() string   { return LoggerName }
+
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (ll *LogfmtLogger) Caller() bool { return ll.root().caller }
+
+func (ll *LogfmtLogger) GetWriter() *os.File {
+	f, _ := ll.GetOutput().(*os.File)
+	return f
+}
+
+func (ll *LogfmtLogger) SetOutput(w io.Writer) {
+	root := ll.root()
+	root.mutex.Lock()
+	root.writer = w
+	root.mutex.Unlock()
+}
+
+func (ll *LogfmtLogger) GetOutput() io.Writer {
+	root := ll.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return root.writer
+}
+
+// Sync commits the output to its storage if supported, e.g. files
+func (ll *LogfmtLogger) Sync() error {
+	root := ll.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.SyncOutput(root.writer)
+}
+
+// Close closes the output if supported, os.Stdout and os.Stderr are not closed
+func (ll *LogfmtLogger) Close() error {
+	root := ll.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.CloseOutput(root.writer)
+}
+
+func (ll *LogfmtLogger) SetLevel(level string) error {
+	root := ll.root()
+	root.level = helpers.ToLevel(level)
+	if root.level == helpers.UnknownLevel {
+		return fmt.Errorf("level '%s' unknown", level)
+	}
+	return nil
+}
+
+// Ctx returns a child logger adding the trace_id, span_id and trace_flags of the span of ctx to every entry, replacing those of a previous Ctx
+func (ll *LogfmtLogger) Ctx(ctx context.Context) helpers.ILogger {
+	trace := helpers.TraceDetails(ctx)
+	if trace == nil && ll.trace == nil {
+		return ll
+	}
+	return &LogfmtLogger{
+		parent: ll.root(),
+		fields: ll.fields,
+		trace:  trace,
+		name:   ll.name,
+	}
+}
+
+// With returns a child logger printing the details with every entry. The child shares the level and the writer of its parent
+func (ll *LogfmtLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return ll
+	}
+	return &LogfmtLogger{
+		parent: ll.root(),
+		fields: append(append([]helpers.IDetails{}, ll.fields...), details...),
+		trace:  ll.trace,
+		name:   ll.name,
+	}
+}
+
+// Named returns a child logger of the component name, printed as the logger key. Its level can be set with helpers.SetComponentLevel
+func (ll *LogfmtLogger) Named(name string) helpers.ILogger {
+	return &LogfmtLogger{
+		parent: ll.root(),
+		fields: ll.fields,
+		trace:  ll.trace,
+		name:   helpers.JoinNames(ll.name, name),
+	}
+}
+
+func (ll *LogfmtLogger) root() *LogfmtLogger {
+	if ll.parent != nil {
+		return ll.parent
+	}
+	return ll
+}
+
+func (ll *LogfmtLogger) effectiveLevel() helpers.Level {
+	return helpers.EffectiveLevel(ll.name, ll.root().level)
+}
+
+// withFields prepends the details and the trace details of the logger to the entry details and prepares them for rendering (see helpers.PrepareDetails)
+func (ll *LogfmtLogger) withFields(level helpers.Level, details []helpers.IDetails) []helpers.IDetails {
+	if len(ll.fields) == 0 && len(ll.trace) == 0 {
+		return helpers.PrepareDetails(level, details, ll.root().caller)
+	}
+	all := make([]helpers.IDetails, 0, len(ll.fields)+len(ll.trace)+len(details))
+	return helpers.PrepareDetails(level, append(append(append(all, ll.fields...), ll.trace...), details...), ll.root().caller)
+}
+
+func (ll *LogfmtLogger) Fatal(msg string, details ...helpers.IDetails) {
+	ll.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (ll *LogfmtLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.FatalLevel, msg, details...)
+	ll.Sync()
+}
+func (ll *LogfmtLogger) Error(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.ErrorLevel, msg, details...)
+}
+func (ll *LogfmtLogger) Warning(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.WarningLevel, msg, details...)
+}
+func (ll *LogfmtLogger) Info(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.InfoLevel, msg, details...)
+}
+func (ll *LogfmtLogger) Debug(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.DebugLevel, msg, details...)
+}
+func (ll *LogfmtLogger) Success(msg string, details ...helpers.IDetails) {
+	ll.print("", helpers.SuccessLevel, msg, details...)
+}
+func (ll *LogfmtLogger) Start(msg string, details ...helpers.IDetails) {
+	ll.print(helpers.StartEvent, helpers.InfoLevel, msg, details...)
+}
+func (ll *LogfmtLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	ll.print(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details...)
+}
+func (ll *LogfmtLogger) StopError(msg string, details ...helpers.IDetails) {
+	ll.print(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details...)
+}
+
+func (ll *LogfmtLogger) print(event string, level helpers.Level, msg string, details ...helpers.IDetails) {
+	if level.Skip(ll.effectiveLevel()) {
+		return
+	}
+	root := ll.root()
+	line := formatLineWithTimeFormat(root.timeFormat, time.Now(), level, ll.name, event, msg, ll.withFields(level, details))
+
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	if root.writer != nil {
+		root.writer.Write(line)
+	}
+}
+
+// formatLineWithTimeFormat returns the logfmt line of the entry, formatting the time with the layout, time.RFC3339 if empty.
+// Groups of details are flattened to dotted keys
+func formatLineWithTimeFormat(layout string, t time.Time, level helpers.Level, name, event, msg string, details []helpers.IDetails) []byte {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	b := &bytes.Buffer{}
+	writePair(b, "time", t.Format(layout))
+	writePair(b, "level", level.String())
+	if name != "" {
+		writePair(b, "logger", name)
+	}
+	if event != "" {
+		writePair(b, "event", event)
+	}
+	writePair(b, "msg", msg)
+	for _, d := range helpers.Flatten(details) {
+		writePair(b, d.Key(), helpers.FormatValue(d.Value()))
+	}
+	b.WriteByte('\n')
+	return b.Bytes()
+}
+
+func writePair(b *bytes.Buffer, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(formatKey(key))
+	b.WriteByte('=')
+	b.WriteString(formatValue(value))
+}
+
+// formatKey replaces the characters a key cannot contain by an underscore
+func formatKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// formatValue quotes values that are empty or contain spaces, equal signs, quotes or non printable characters
+func formatValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
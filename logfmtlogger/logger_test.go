@@ -0,0 +1,114 @@
+package logfmtlogger
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    helpers.Level
+		logger   string
+		event    string
+		msg      string
+		details  []helpers.IDetails
+		expected string
+	}{
+		{
+			"Simple",
+			helpers.InfoLevel,
+			"",
+			"",
+			"hello",
+			nil,
+			"time=2024-01-02T03:04:05Z level=info msg=hello\n",
+		},
+		{
+			"Quoting",
+			helpers.WarningLevel,
+			"scanner.rbac",
+			helpers.StartEvent,
+			"scan started",
+			[]helpers.IDetails{
+				helpers.String("path", `C:\temp`),
+				helpers.String("query", "a=b"),
+				helpers.String("quote", `say "hi"`),
+				helpers.String("empty", ""),
+				helpers.String("multi line", "a\nb"),
+				helpers.Error(fmt.Errorf("timeout")),
+			},
+			`time=2024-01-02T03:04:05Z level=warning logger=scanner.rbac event=start msg="scan started" path="C:\\temp" query="a=b" quote="say \"hi\"" empty="" multi_line="a\nb" error=timeout` + "\n",
+		},
+		{
+			"Groups",
+			helpers.ErrorLevel,
+			"",
+			"",
+			"control",
+			[]helpers.IDetails{
+				helpers.Group("resource", helpers.String("kind", "Pod"), helpers.Int("replicas", 2)),
+				helpers.Strings("namespaces", []string{"default", "kube-system"}),
+			},
+			`time=2024-01-02T03:04:05Z level=error msg=control resource.kind=Pod resource.replicas=2 namespaces="[default, kube-system]"` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := formatLineWithTimeFormat("", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), tt.level, tt.logger, tt.event, tt.msg, tt.details)
+			assert.Equal(t, tt.expected, string(line))
+		})
+	}
+}
+
+func TestLogfmtLoggerPrint(t *testing.T) {
+	defer helpers.ResetComponentLevels()
+
+	b := &bytes.Buffer{}
+	logger := NewLogfmtLogger()
+	logger.SetOutput(b)
+	assert.Equal(t, b, logger.GetOutput())
+	assert.NoError(t, helpers.SetComponentLevel("scanner", "debug"))
+
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234"))
+	child.Debug("resources", helpers.Int("count", 3))
+	logger.Debug("not printed")
+	child.StopSuccess("done")
+
+	assert.Regexp(t, regexp.MustCompile(`^time=\S+ level=debug logger=scanner msg=resources scanID=1234 count=3
+time=\S+ level=success logger=scanner event=stop_success msg=done scanID=1234
+$`), b.String())
+}
+
+func TestLogfmtLoggerSetLevel(t *testing.T) {
+	logger := NewLogfmtLogger()
+	assert.Equal(t, "info", logger.GetLevel())
+	assert.NoError(t, logger.With(helpers.String("a", "b")).SetLevel("warning"))
+	assert.Equal(t, "warning", logger.GetLevel())
+	assert.Error(t, logger.SetLevel("verbose"))
+}
+
+func TestLogfmtLoggerLoggerName(t *testing.T) {
+	assert.Equal(t, LoggerName, NewLogfmtLogger().LoggerName())
+}
+
+func TestNewLogfmtLoggerOptions(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewLogfmtLogger(
+		helpers.WithWriter(b),
+		helpers.WithLevel(helpers.WarningLevel),
+		helpers.WithTimeFormat(time.DateOnly),
+		helpers.WithFields(helpers.String("pod", "kubescape-0")),
+	)
+	logger.Info("filtered")
+	logger.Warning("slow")
+
+	assert.Regexp(t, regexp.MustCompile(`^time=\d{4}-\d{2}-\d{2} level=warning msg=slow pod=kubescape-0\n$`), b.String())
+}
@@ -0,0 +1,93 @@
+package logrlogger
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kubescape/go-logger/helpers"
+)
+
+var _ logr.LogSink = (*LogSink)(nil)
+
+// LogSink is a logr.LogSink logging with a helpers.ILogger, so controller-runtime and client-go follow the configured logger:
+//
+//	ctrl.SetLogger(logrlogger.NewLogger(logger.L()))
+//	klog.SetLogger(logrlogger.NewLogger(logger.L()))
+//
+// V(0) is logged at the info level and higher verbosities at the debug level.
+// WithValues adds the key/value pairs as details of a child logger (see helpers.ILogger.With) and WithName names a child logger (see helpers.ILogger.Named)
+type LogSink struct {
+	logger helpers.ILogger
+}
+
+// NewLogSink returns a logr.LogSink logging with l
+func NewLogSink(l helpers.ILogger) *LogSink {
+	return &LogSink{logger: l}
+}
+
+// NewLogger returns a logr.Logger logging with l
+func NewLogger(l helpers.ILogger) logr.Logger {
+	return logr.New(NewLogSink(l))
+}
+
+func (ls *LogSink) Init(_ logr.RuntimeInfo) {}
+
+func (ls *LogSink) Enabled(level int) bool {
+	return !verbosityLevel(level).Skip(helpers.ToLevel(ls.logger.GetLevel()))
+}
+
+func (ls *LogSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if verbosityLevel(level) == helpers.DebugLevel {
+		ls.logger.Debug(msg, keysAndValuesToDetails(keysAndValues)...)
+		return
+	}
+	ls.logger.Info(msg, keysAndValuesToDetails(keysAndValues)...)
+}
+
+func (ls *LogSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	details := keysAndValuesToDetails(keysAndValues)
+	if err != nil {
+		details = append([]helpers.IDetails{helpers.Error(err)}, details...)
+	}
+	ls.logger.Error(msg, details...)
+}
+
+func (ls *LogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &LogSink{logger: ls.logger.With(keysAndValuesToDetails(keysAndValues)...)}
+}
+
+func (ls *LogSink) WithName(name string) logr.LogSink {
+	return &LogSink{logger: ls.logger.Named(name)}
+}
+
+// verbosityLevel returns the level of a logr verbosity
+func verbosityLevel(level int) helpers.Level {
+	if level > 0 {
+		return helpers.DebugLevel
+	}
+	return helpers.InfoLevel
+}
+
+// keysAndValuesToDetails converts logr key/value pairs to details. Keys that are not strings are formatted and a missing value is logged as "<no-value>"
+func keysAndValuesToDetails(keysAndValues []interface{}) []helpers.IDetails {
+	details := make([]helpers.IDetails, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", keysAndValues[i])
+		}
+		var value interface{} = "<no-value>"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		if m, ok := value.(logr.Marshaler); ok {
+			value = m.MarshalLog()
+		}
+		if s, ok := value.(string); ok {
+			details = append(details, helpers.String(key, s))
+			continue
+		}
+		details = append(details, helpers.Interface(key, value))
+	}
+	return details
+}
@@ -0,0 +1,42 @@
+package logrlogger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+type secret string
+
+func (s secret) MarshalLog() interface{} { return "***" }
+
+func TestLogSink(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+	defer helpers.ResetComponentLevels()
+
+	b := &bytes.Buffer{}
+	l := prettylogger.NewPrettyLogger()
+	l.SetOutput(b)
+
+	logger := NewLogger(l)
+	assert.True(t, logger.Enabled())
+	assert.False(t, logger.V(1).Enabled())
+
+	logger.V(1).Info("not printed")
+	reconciler := logger.WithName("controller").WithName("pod").WithValues("namespace", "default")
+	reconciler.Info("reconciling", "name", "nginx", "token", secret("abc"), 42)
+	reconciler.Error(fmt.Errorf("not found"), "failed", "retry", true)
+
+	assert.NoError(t, helpers.SetComponentLevel("controller.pod", "debug"))
+	assert.True(t, reconciler.V(2).Enabled())
+	reconciler.V(2).Info("details")
+
+	assert.Equal(t, "[info] [controller.pod] reconciling. namespace: default; name: nginx; token: [REDACTED]; 42: <no-value>\n"+
+		"[error] [controller.pod] failed. namespace: default; error: not found; retry: true\n"+
+		"[debug] [controller.pod] details. namespace: default\n", b.String())
+}
@@ -2,16 +2,28 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/kubescape/go-logger/filewriter"
 	"github.com/kubescape/go-logger/helpers"
 	"github.com/kubescape/go-logger/iconlogger"
+	"github.com/kubescape/go-logger/logfmtlogger"
+	"github.com/kubescape/go-logger/multilogger"
 	"github.com/kubescape/go-logger/nonelogger"
+	"github.com/kubescape/go-logger/otellogger"
 	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/kubescape/go-logger/sloglogger"
 	"github.com/kubescape/go-logger/zaplogger"
 	"github.com/uptrace/uptrace-go/uptrace"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -20,16 +32,99 @@ const (
 	EnvLoggerLevel = "KS_LOGGER_LEVEL"
 	// Logger name environment name
 	EnvLoggerName = "KS_LOGGER_NAME"
+	// Logger output format environment name, "text" or "json". Supported by the pretty and icon loggers
+	EnvLoggerFormat = "KS_LOGGER_FORMAT"
+	// Component levels environment name, e.g. "scanner.rbac=debug,scanner=warning"
+	EnvLoggerComponentLevels = "KS_LOGGER_COMPONENT_LEVELS"
+	// Log file environment name, the loggers write to the file instead of stderr
+	EnvLoggerFile = "KS_LOGGER_FILE"
+	// Log file max size environment name, in megabytes
+	EnvLoggerFileMaxSize = "KS_LOGGER_FILE_MAX_SIZE"
+	// Log file rotation interval environment name, e.g. "24h"
+	EnvLoggerFileRotationInterval = "KS_LOGGER_FILE_ROTATION_INTERVAL"
+	// Log file backups max age environment name, e.g. "168h"
+	EnvLoggerFileMaxAge = "KS_LOGGER_FILE_MAX_AGE"
+	// Log file max backups environment name
+	EnvLoggerFileMaxBackups = "KS_LOGGER_FILE_MAX_BACKUPS"
+	// Log file backups compression environment name, "true" to compress the backups with gzip
+	EnvLoggerFileCompress = "KS_LOGGER_FILE_COMPRESS"
+	// Error stacks environment name, "true" to log the call stack of helpers.Error with the error and fatal entries
+	EnvLoggerErrorStack = "KS_LOGGER_ERROR_STACK"
+	// Caller environment name, "true" to log the call site of the entries
+	EnvLoggerCaller = "KS_LOGGER_CALLER"
+	// Stack trace environment name, "true" to log the call stack of the error and fatal entries
+	EnvLoggerStacktrace = "KS_LOGGER_STACKTRACE"
 )
 
-var l helpers.ILogger
+// loggerHolder holds the global logger, atomic.Pointer cannot point to an interface
+type loggerHolder struct {
+	logger helpers.ILogger
+	file   *filewriter.FileWriter // log file opened from KS_LOGGER_FILE, closed when the logger is replaced
+}
+
+var (
+	global      atomic.Pointer[loggerHolder]
+	globalMutex sync.Mutex // serializes the initialization of the default logger and the replacements of the global logger
+)
+
+// formatter is implemented by the loggers supporting several output formats
+type formatter interface {
+	SetFormat(format string) error
+}
 
-// Return initialized logger. If logger not initialized, will call InitializeLogger() with the default value
+// Return initialized logger. If logger not initialized, will call InitializeLogger() with the default value.
+// It is safe to call L concurrently, including with InitLogger and SetLogger
 func L() helpers.ILogger {
+	if h := global.Load(); h != nil {
+		return h.logger
+	}
+	// the first goroutines to log wait for the default logger initialized once by one of them
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	if h := global.Load(); h != nil {
+		return h.logger
+	}
+	h := newLogger("")
+	global.Store(h)
+	return h.logger
+}
+
+// SetLogger installs l as the global logger returned by L, e.g. a custom helpers.ILogger implementation.
+// Setting nil resets the global logger, the next call to L will initialize the default logger
+func SetLogger(l helpers.ILogger) {
+	setGlobal(holderOf(l))
+}
+
+// ReplaceGlobals installs l as the global logger and returns a function restoring the previous one, e.g. in tests:
+//
+//	defer logger.ReplaceGlobals(nonelogger.NewNoneLogger())()
+func ReplaceGlobals(l helpers.ILogger) func() {
+	previous := swapGlobal(holderOf(l))
+	return func() {
+		setGlobal(previous)
+	}
+}
+
+// setGlobal installs h as the global logger and closes the log file of the previous one
+func setGlobal(h *loggerHolder) {
+	if previous := swapGlobal(h); previous != nil && previous.file != nil && previous != h {
+		previous.file.Close()
+	}
+}
+
+// holderOf returns the holder of l, nil if l is nil
+func holderOf(l helpers.ILogger) *loggerHolder {
 	if l == nil {
-		InitDefaultLogger()
+		return nil
 	}
-	return l
+	return &loggerHolder{logger: l}
+}
+
+// swapGlobal installs h as the global logger and returns the previous one
+func swapGlobal(h *loggerHolder) *loggerHolder {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	return global.Swap(h)
 }
 
 /*
@@ -43,42 +138,211 @@ Supported logger names (call ListLoggersNames() for listing supported loggers)
 - "pretty", "colorful": Human friendly colorful logger
 - "none", "mock", "empty", "ignore": Logger will not print anything
 - "icon", "emoji": Human friendly logger with colors and icons/symbols
+- "logfmt": key=value lines
+- "slog": Logger from package "log/slog" with the text handler
+- "otel": OpenTelemetry log records emitted with the global logger provider (see InitOTLP)
+
+Several comma separated names, e.g. "icon,zap", initialize a multilogger.MultiLogger dispatching the entries to all the loggers
 
 Default:
 - "pretty"
 
 If the logger name is empty, will try to get the logger name from the environment variable KS_LOGGER_NAME.
 If the logger level environment variable is set, will set the logger level to the value of the environment variable.
+If the logger format environment variable is set, will set the output format of the pretty and icon loggers.
+If the component levels environment variable is set, will set the level of each listed component (see helpers.SetComponentLevel).
+If the log file environment variable is set, will write to the file, rotated according to the KS_LOGGER_FILE_* environment variables (see filewriter.Config).
+With several loggers, the pretty and icon loggers keep writing to the terminal unless all the loggers are pretty or icon loggers.
+The file is closed when the global logger is replaced, e.g. by InitLogger or SetLogger.
+If the error stack environment variable is set, will enable the capture of the call stack by helpers.Error (see helpers.SetErrorStacks).
+If the caller and stack trace environment variables are set, will log the call site of the entries and the call stack of the error and fatal entries (see helpers.SetCaller and helpers.SetStacktrace).
 
 e.g.
 InitLogger("none") -> will initialize the mock logger
 */
 func InitLogger(loggerName string) {
+	setGlobal(newLogger(loggerName))
+}
+
+// InitLoggerWithOptions initializes the logger of the name as InitLogger does, constructed with the options, e.g.
+//
+//	InitLoggerWithOptions("zap", helpers.WithLevel(helpers.DebugLevel), helpers.WithFields(helpers.String("pod", podName)))
+//
+// The environment variables override the options
+func InitLoggerWithOptions(loggerName string, opts ...helpers.Option) {
+	setGlobal(newLogger(loggerName, opts...))
+}
+
+// newLogger returns the holder of the logger of the name constructed with the options, configured from the environment variables.
+// Several comma separated names return a multilogger.MultiLogger of the loggers
+func newLogger(loggerName string, opts ...helpers.Option) *loggerHolder {
+	var l helpers.ILogger
+	var loggers []helpers.ILogger
+	var file *filewriter.FileWriter
 
 	if loggerName == "" {
 		// get logger name from environment variable
 		loggerName = os.Getenv(EnvLoggerName)
 	}
 
+	if names := strings.Split(loggerName, ","); len(names) > 1 {
+		loggers = make([]helpers.ILogger, 0, len(names))
+		for _, name := range names {
+			loggers = append(loggers, newBackend(strings.TrimSpace(name), opts...))
+		}
+		l = multilogger.NewMultiLogger(loggers...)
+	} else {
+		l = newBackend(loggerName, opts...)
+		loggers = []helpers.ILogger{l}
+	}
+
+	// set the output to a rotating file from environment variables
+	if filename := os.Getenv(EnvLoggerFile); filename != "" {
+		w, err := newFileWriter(filename)
+		if err != nil {
+			l.Warning("failed to open log file", helpers.String("environment", EnvLoggerFile), helpers.Error(err))
+		} else {
+			for _, fileLogger := range fileLoggers(loggers) {
+				fileLogger.SetOutput(w)
+			}
+			w.ReopenOnSIGHUP() // stopped by w.Close
+			file = w
+		}
+	}
+
+	// set logger level from environment variable, if empty, will use the default value as set by the package
+	if lev := os.Getenv(EnvLoggerLevel); lev != "" {
+		if err := l.SetLevel(lev); err != nil {
+			l.Warning("failed to set logger level", helpers.String("environment", EnvLoggerLevel), helpers.Error(err))
+		}
+	}
+
+	// set output format from environment variable, the zap logger is always JSON
+	if format := os.Getenv(EnvLoggerFormat); format != "" {
+		if f, ok := l.(formatter); ok {
+			if err := f.SetFormat(format); err != nil {
+				l.Warning("failed to set logger format", helpers.String("environment", EnvLoggerFormat), helpers.Error(err))
+			}
+		}
+	}
+
+	// set component levels from environment variable, formatted as "<component>=<level>,<component>=<level>"
+	if levels := os.Getenv(EnvLoggerComponentLevels); levels != "" {
+		if err := setComponentLevels(levels); err != nil {
+			l.Warning("failed to set component levels", helpers.String("environment", EnvLoggerComponentLevels), helpers.Error(err))
+		}
+	}
+
+	// enable the error stacks, the call sites and the stack traces from environment variables
+	for _, toggle := range []struct {
+		env string
+		set func(enabled bool)
+	}{
+		{env: EnvLoggerErrorStack, set: helpers.SetErrorStacks},
+		{env: EnvLoggerCaller, set: helpers.SetCaller},
+		{env: EnvLoggerStacktrace, set: helpers.SetStacktrace},
+	} {
+		if value := os.Getenv(toggle.env); value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				l.Warning("failed to parse boolean", helpers.String("environment", toggle.env), helpers.Error(err))
+				continue
+			}
+			toggle.set(enabled)
+		}
+	}
+
+	return &loggerHolder{logger: l, file: file}
+}
+
+// fileLoggers returns the loggers writing to the log file: the pretty and icon loggers keep the terminal when other loggers write to the file,
+// e.g. "icon,zap" prints the icon logger entries and writes the zap JSON entries to the file
+func fileLoggers(loggers []helpers.ILogger) []helpers.ILogger {
+	var files []helpers.ILogger
+	for _, l := range loggers {
+		if name := l.LoggerName(); name != prettylogger.LoggerName && name != iconlogger.LoggerName {
+			files = append(files, l)
+		}
+	}
+	if len(files) == 0 {
+		return loggers
+	}
+	return files
+}
+
+// newBackend returns the logger of the name constructed with the options, the pretty logger if the name is unknown
+func newBackend(loggerName string, opts ...helpers.Option) helpers.ILogger {
 	switch strings.ToLower(loggerName) {
 	case zaplogger.LoggerName:
-		l = zaplogger.NewZapLogger()
+		return zaplogger.NewZapLogger(opts...)
 	case prettylogger.LoggerName, "colorful":
-		l = prettylogger.NewPrettyLogger()
+		return prettylogger.NewPrettyLogger(opts...)
 	case iconlogger.LoggerName, "emoji":
-		l = iconlogger.NewIconLogger()
+		return iconlogger.NewIconLogger(opts...)
+	case logfmtlogger.LoggerName:
+		return logfmtlogger.NewLogfmtLogger(opts...)
+	case sloglogger.LoggerName:
+		return sloglogger.NewSlogLogger(opts...)
+	case otellogger.LoggerName:
+		return otellogger.NewOtelLogger(opts...)
 	case nonelogger.LoggerName, "mock", "empty", "ignore":
-		l = nonelogger.NewNoneLogger()
-	default:
-		l = prettylogger.NewPrettyLogger()
+		return nonelogger.NewNoneLogger(opts...)
 	}
+	return prettylogger.NewPrettyLogger(opts...)
+}
 
-	// set logger level from environment variable, if empty, will use the default value as set by the package
-	if lev := os.Getenv(EnvLoggerLevel); lev != "" {
-		if err := l.SetLevel(lev); err != nil {
-			l.Warning("failed to set logger level", helpers.String("environment", EnvLoggerLevel), helpers.Error(err))
+// newFileWriter returns the file writer of the filename, configured from the KS_LOGGER_FILE_* environment variables
+func newFileWriter(filename string) (*filewriter.FileWriter, error) {
+	cfg := filewriter.Config{Filename: filename}
+	if maxSize := os.Getenv(EnvLoggerFileMaxSize); maxSize != "" {
+		megabytes, err := strconv.ParseInt(maxSize, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", EnvLoggerFileMaxSize, maxSize, err)
+		}
+		cfg.MaxSize = megabytes * 1024 * 1024
+	}
+	if interval := os.Getenv(EnvLoggerFileRotationInterval); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", EnvLoggerFileRotationInterval, interval, err)
+		}
+		cfg.Interval = d
+	}
+	if maxAge := os.Getenv(EnvLoggerFileMaxAge); maxAge != "" {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", EnvLoggerFileMaxAge, maxAge, err)
+		}
+		cfg.MaxAge = d
+	}
+	if maxBackups := os.Getenv(EnvLoggerFileMaxBackups); maxBackups != "" {
+		n, err := strconv.Atoi(maxBackups)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", EnvLoggerFileMaxBackups, maxBackups, err)
+		}
+		cfg.MaxBackups = n
+	}
+	if compress := os.Getenv(EnvLoggerFileCompress); compress != "" {
+		b, err := strconv.ParseBool(compress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", EnvLoggerFileCompress, compress, err)
+		}
+		cfg.Compress = b
+	}
+	return filewriter.NewFileWriter(cfg)
+}
+
+func setComponentLevels(levels string) error {
+	for _, componentLevel := range strings.Split(levels, ",") {
+		component, level, found := strings.Cut(strings.TrimSpace(componentLevel), "=")
+		if !found || component == "" {
+			return fmt.Errorf("invalid component level '%s', expected <component>=<level>", componentLevel)
+		}
+		if err := helpers.SetComponentLevel(component, level); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 func InitDefaultLogger() {
@@ -94,11 +358,12 @@ func EnableColor(flag bool) {
 }
 
 func ListLoggersNames() []string {
-	return []string{prettylogger.LoggerName, iconlogger.LoggerName, zaplogger.LoggerName, nonelogger.LoggerName}
+	return []string{prettylogger.LoggerName, iconlogger.LoggerName, zaplogger.LoggerName, logfmtlogger.LoggerName, sloglogger.LoggerName, otellogger.LoggerName, nonelogger.LoggerName}
 }
 
 // InitOtel configures OpenTelemetry to export data to OTEL_COLLECTOR_SVC using uptrace collector.
 // You have to set the env variable OTEL_COLLECTOR_SVC to enable otel.
+// Use InitOTLP to export to any OpenTelemetry collector.
 // It is required to call ShutdownOtel on the context at the end of the main.
 //
 //	func main() {
@@ -141,6 +406,22 @@ func InitOtel(serviceName, version, accountId, clusterName string, collectorUrl
 	return ctx
 }
 
+// Shutdown syncs the global logger and shuts down the otel providers configured by InitOtel or InitOTLP. Call it at the end of the main
+//
+//	func main() {
+//	  ctx := context.Background()
+//	  defer logger.Shutdown(ctx)
+//	  ...
+//	}
+func Shutdown(ctx context.Context) error {
+	return errors.Join(L().Sync(), uptrace.Shutdown(ctx), shutdownOTLP(ctx))
+}
+
+// ShutdownOtel flushes and shuts down the providers configured by InitOtel or InitOTLP.
+// The errors are reported to the otel error handler
 func ShutdownOtel(ctx context.Context) {
 	uptrace.Shutdown(ctx)
+	if err := shutdownOTLP(ctx); err != nil {
+		otel.Handle(err)
+	}
 }
@@ -1,12 +1,30 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/kubescape/go-logger/filewriter"
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/hooklogger"
+	"github.com/kubescape/go-logger/iconlogger"
+	"github.com/kubescape/go-logger/logfmtlogger"
+	"github.com/kubescape/go-logger/multilogger"
 	"github.com/kubescape/go-logger/nonelogger"
+	"github.com/kubescape/go-logger/otellogger"
 	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/kubescape/go-logger/sloglogger"
 	"github.com/kubescape/go-logger/zaplogger"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -94,6 +112,56 @@ func TestInitLogger(t *testing.T) {
 				loggerLevel: "warning",
 			},
 		},
+		{
+			name: "TestInitLogger logfmt",
+			want: args{
+				loggerName:  logfmtlogger.LoggerName,
+				loggerLevel: "debug",
+			},
+			args: args{},
+			envs: envs{
+				loggerLevel: "debug",
+				loggerName:  "logfmt",
+			},
+		},
+		{
+			name: "TestInitLogger slog",
+			want: args{
+				loggerName:  sloglogger.LoggerName,
+				loggerLevel: "warning",
+			},
+			args: args{
+				loggerName: "slog",
+			},
+			envs: envs{
+				loggerLevel: "warning",
+			},
+		},
+		{
+			name: "TestInitLogger otel",
+			want: args{
+				loggerName:  otellogger.LoggerName,
+				loggerLevel: "error",
+			},
+			args: args{
+				loggerName: "otel",
+			},
+			envs: envs{
+				loggerLevel: "error",
+			},
+		},
+		{
+			name: "TestInitLogger multi",
+			want: args{
+				loggerName:  multilogger.LoggerName,
+				loggerLevel: "debug",
+			},
+			args: args{},
+			envs: envs{
+				loggerLevel: "debug",
+				loggerName:  "icon, zap",
+			},
+		},
 		{
 			name: "TestInitLogger none",
 			want: args{
@@ -136,12 +204,246 @@ func TestInitLogger(t *testing.T) {
 
 			InitLogger(tt.args.loggerName)
 
-			if l.GetLevel() != tt.want.loggerLevel {
-				t.Errorf("GetLevel() = %v, want %v", l.GetLevel(), tt.want.loggerLevel)
+			if L().GetLevel() != tt.want.loggerLevel {
+				t.Errorf("GetLevel() = %v, want %v", L().GetLevel(), tt.want.loggerLevel)
 			}
-			if l.LoggerName() != tt.want.loggerName {
-				t.Errorf("LoggerName() = %v, want %v", l.LoggerName(), tt.want.loggerName)
+			if L().LoggerName() != tt.want.loggerName {
+				t.Errorf("LoggerName() = %v, want %v", L().LoggerName(), tt.want.loggerName)
 			}
 		})
 	}
 }
+
+func TestSetComponentLevels(t *testing.T) {
+	defer helpers.ResetComponentLevels()
+
+	assert.NoError(t, setComponentLevels("scanner.rbac=debug, scanner=warning"))
+	level, ok := helpers.ComponentLevel("scanner.rbac.roles")
+	assert.True(t, ok)
+	assert.Equal(t, helpers.DebugLevel, level)
+	level, ok = helpers.ComponentLevel("scanner.network")
+	assert.True(t, ok)
+	assert.Equal(t, helpers.WarningLevel, level)
+	_, ok = helpers.ComponentLevel("scannerx")
+	assert.False(t, ok)
+
+	assert.Error(t, setComponentLevels("scanner"))
+	assert.Error(t, setComponentLevels("scanner=verbose"))
+}
+
+func TestL(t *testing.T) {
+	os.Setenv(EnvLoggerName, "")
+	os.Setenv(EnvLoggerLevel, "")
+	SetLogger(nil)
+
+	// all the goroutines racing to initialize the default logger get the same one
+	var wg sync.WaitGroup
+	loggers := make([]helpers.ILogger, 100)
+	for i := range loggers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			loggers[i] = L()
+		}(i)
+	}
+	wg.Wait()
+	for i := range loggers {
+		assert.Same(t, L(), loggers[i])
+	}
+	assert.Equal(t, prettylogger.LoggerName, L().LoggerName())
+}
+
+func TestLInitializesOnce(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "kubescape.log")
+	t.Setenv(EnvLoggerName, logfmtlogger.LoggerName)
+	t.Setenv(EnvLoggerFile, filename)
+	t.Setenv(EnvLoggerLevel, "verbose") // each initialization writes a warning to the file
+	defer ReplaceGlobals(nil)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			L()
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, L().Close())
+
+	b, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(b), "failed to set logger level"))
+}
+
+func TestSetLoggerAndReplaceGlobals(t *testing.T) {
+	pretty := prettylogger.NewPrettyLogger()
+	SetLogger(pretty)
+	assert.Same(t, pretty, L())
+
+	none := nonelogger.NewNoneLogger()
+	restore := ReplaceGlobals(none)
+	assert.Same(t, none, L())
+
+	// concurrent readers while replacing the logger
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			L().Info("concurrent")
+		}()
+		go func() {
+			defer wg.Done()
+			ReplaceGlobals(none)
+		}()
+	}
+	wg.Wait()
+
+	restore()
+	assert.Same(t, pretty, L())
+}
+
+func TestInitLoggerFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "kubescape.log")
+	t.Setenv(EnvLoggerFile, filename)
+	t.Setenv(EnvLoggerFileMaxSize, "10")
+	t.Setenv(EnvLoggerFileMaxBackups, "3")
+	t.Setenv(EnvLoggerFileCompress, "true")
+
+	l := newLogger(logfmtlogger.LoggerName).logger
+	l.Info("written to the file")
+	_, ok := l.GetOutput().(*filewriter.FileWriter)
+	assert.True(t, ok)
+	assert.NoError(t, l.Close())
+	b, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `msg="written to the file"`)
+
+	t.Setenv(EnvLoggerFileMaxAge, "7d")
+	_, err = newFileWriter(filename)
+	assert.ErrorContains(t, err, EnvLoggerFileMaxAge)
+}
+
+func TestInitLoggerFileMulti(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "kubescape.log")
+	t.Setenv(EnvLoggerFile, filename)
+
+	// the icon logger keeps its writer, the logfmt logger writes to the file
+	b := &bytes.Buffer{}
+	l := newLogger("icon,logfmt", helpers.WithWriter(b)).logger
+	l.Info("scanning")
+	assert.NoError(t, l.Close())
+	assert.Contains(t, b.String(), "scanning")
+	assert.NotContains(t, b.String(), "msg=")
+	file, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Contains(t, string(file), `msg=scanning`)
+}
+
+func TestInitLoggerFileReplaced(t *testing.T) {
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("the open files are listed in /proc/self/fd")
+	}
+	filename := filepath.Join(t.TempDir(), "kubescape.log")
+	t.Setenv(EnvLoggerFile, filename)
+	defer ReplaceGlobals(nil)()
+
+	for i := 0; i < 3; i++ {
+		InitLogger(logfmtlogger.LoggerName)
+		L().Info("written to the file")
+	}
+	assert.Equal(t, 1, openFiles(t, filename))
+	SetLogger(nonelogger.NewNoneLogger())
+	assert.Equal(t, 0, openFiles(t, filename))
+}
+
+// openFiles returns the number of descriptors of the process opened on filename
+func openFiles(t *testing.T, filename string) int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	assert.NoError(t, err)
+	n := 0
+	for _, e := range entries {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", e.Name())); err == nil && target == filename {
+			n++
+		}
+	}
+	return n
+}
+
+// syncBuffer records the calls to Sync and Close
+type syncBuffer struct {
+	bytes.Buffer
+	synced, closed bool
+}
+
+func (b *syncBuffer) Sync() error  { b.synced = true; return nil }
+func (b *syncBuffer) Close() error { b.closed = true; return nil }
+
+func TestShutdown(t *testing.T) {
+	b := &syncBuffer{}
+	l := prettylogger.NewPrettyLogger()
+	l.SetOutput(b)
+	defer ReplaceGlobals(l)()
+
+	assert.NoError(t, Shutdown(context.Background()))
+	assert.True(t, b.synced)
+	assert.False(t, b.closed)
+}
+
+func TestInitLoggerCaller(t *testing.T) {
+	t.Setenv(EnvLoggerFormat, helpers.JSONFormat)
+	t.Setenv(EnvLoggerCaller, "true")
+	t.Setenv(EnvLoggerStacktrace, "true")
+	defer helpers.SetCaller(false)
+	defer helpers.SetStacktrace(false)
+
+	for _, name := range []string{prettylogger.LoggerName, iconlogger.LoggerName, zaplogger.LoggerName} {
+		t.Run(name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			l := newLogger(name).logger
+			l.SetOutput(b)
+			// the frames of the wrappers are skipped too
+			defer ReplaceGlobals(hooklogger.NewHookLogger(multilogger.NewMultiLogger(l)))()
+
+			_, file, line, _ := runtime.Caller(0)
+			L().Info("info")
+			L().Named("scanner").Error("error")
+
+			caller := path.Base(path.Dir(file)) + "/" + path.Base(file)
+			lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+			assert.Len(t, lines, 2)
+			for i, expectedLine := range []int{line + 1, line + 2} {
+				entry := map[string]interface{}{}
+				assert.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+				assert.Equal(t, fmt.Sprintf("%s:%d", caller, expectedLine), entry["caller"])
+				assert.Equal(t, "github.com/kubescape/go-logger.TestInitLoggerCaller.func1", entry["function"])
+			}
+
+			// the stack trace is logged with the error entries
+			entry := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+			assert.NotContains(t, entry, "stacktrace")
+			assert.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+			assert.True(t, strings.HasPrefix(entry["stacktrace"].(string), "github.com/kubescape/go-logger.TestInitLoggerCaller.func1\n\t"+file))
+		})
+	}
+}
+
+func TestInitLoggerWithOptions(t *testing.T) {
+	b := &bytes.Buffer{}
+	defer ReplaceGlobals(nil)()
+
+	InitLoggerWithOptions("logfmt,pretty", helpers.WithWriter(b), helpers.WithLevel(helpers.DebugLevel), helpers.WithColor(false))
+	l, ok := L().(*multilogger.MultiLogger)
+	assert.True(t, ok)
+	assert.Equal(t, "debug", l.GetLevel())
+	for _, backend := range l.Loggers() {
+		assert.Equal(t, b, backend.GetOutput())
+	}
+
+	// the environment variables override the options
+	t.Setenv(EnvLoggerLevel, "warning")
+	InitLoggerWithOptions(logfmtlogger.LoggerName, helpers.WithWriter(b), helpers.WithLevel(helpers.DebugLevel))
+	assert.Equal(t, "warning", L().GetLevel())
+}
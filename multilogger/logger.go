@@ -0,0 +1,225 @@
+package multilogger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "multi"
+
+// MultiLogger dispatches every entry to several loggers, e.g. human friendly output to the terminal and JSON to a file.
+// Each logger filters the entries with its own level and writes to its own output
+type MultiLogger struct {
+	loggers []helpers.ILogger
+}
+
+var _ helpers.ILogger = (*MultiLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*MultiLogger)(nil)
+var _ helpers.CallerLogger = (*MultiLogger)(nil)
+
+func NewMultiLogger(loggers ...helpers.ILogger) *MultiLogger {
+	return &MultiLogger{loggers: loggers}
+}
+
+// Loggers returns the loggers the entries are dispatched to, e.g. to set their levels individually
+func (ml *MultiLogger) Loggers() []helpers.ILogger { return ml.loggers }
+
+func (ml *MultiLogger) LoggerName() string { return LoggerName }
+
+// Caller reports whether one of the loggers adds the call site of its entries, see helpers.CallerLogger
+func (ml *MultiLogger) Caller() bool {
+	for _, l := range ml.loggers {
+		if helpers.LogsCaller(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLevel returns the lowest level of the loggers
+func (ml *MultiLogger) GetLevel() string {
+	level := helpers.UnknownLevel
+	for _, l := range ml.loggers {
+		if lev := helpers.ToLevel(l.GetLevel()); lev != helpers.UnknownLevel && (level == helpers.UnknownLevel || lev < level) {
+			level = lev
+		}
+	}
+	if level == helpers.UnknownLevel {
+		return ""
+	}
+	return level.String()
+}
+
+// SetLevel sets the level of all the loggers, use Loggers to set their levels individually
+func (ml *MultiLogger) SetLevel(level string) error {
+	var errs []error
+	for _, l := range ml.loggers {
+		errs = append(errs, l.SetLevel(level))
+	}
+	return errors.Join(errs...)
+}
+
+// SetFormat sets the output format of the loggers supporting several formats
+func (ml *MultiLogger) SetFormat(format string) error {
+	var errs []error
+	for _, l := range ml.loggers {
+		if f, ok := l.(interface{ SetFormat(string) error }); ok {
+			errs = append(errs, f.SetFormat(format))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sync syncs all the loggers
+func (ml *MultiLogger) Sync() error {
+	var errs []error
+	for _, l := range ml.loggers {
+		errs = append(errs, l.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes all the loggers
+func (ml *MultiLogger) Close() error {
+	var errs []error
+	for _, l := range ml.loggers {
+		errs = append(errs, l.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (ml *MultiLogger) SetWriter(w *os.File) { ml.SetOutput(w) }
+
+func (ml *MultiLogger) GetWriter() *os.File {
+	f, _ := ml.GetOutput().(*os.File)
+	return f
+}
+
+// SetOutput sets the output of all the loggers, use Loggers to set their outputs individually
+func (ml *MultiLogger) SetOutput(w io.Writer) {
+	for _, l := range ml.loggers {
+		l.SetOutput(w)
+	}
+}
+
+// GetOutput returns the output of the loggers if they share it, an io.MultiWriter of their outputs otherwise
+func (ml *MultiLogger) GetOutput() io.Writer {
+	var outputs []io.Writer
+	for _, l := range ml.loggers {
+		if w := l.GetOutput(); w != nil && w != io.Discard && !contains(outputs, w) {
+			outputs = append(outputs, w)
+		}
+	}
+	switch len(outputs) {
+	case 0:
+		return io.Discard
+	case 1:
+		return outputs[0]
+	}
+	return io.MultiWriter(outputs...)
+}
+
+// Ctx returns a multi logger of the child loggers of ctx
+func (ml *MultiLogger) Ctx(ctx context.Context) helpers.ILogger {
+	return ml.children(func(l helpers.ILogger) helpers.ILogger { return l.Ctx(ctx) })
+}
+
+// With returns a multi logger of the child loggers adding the details to every entry
+func (ml *MultiLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return ml
+	}
+	return ml.children(func(l helpers.ILogger) helpers.ILogger { return l.With(details...) })
+}
+
+// Named returns a multi logger of the child loggers of the component name
+func (ml *MultiLogger) Named(name string) helpers.ILogger {
+	return ml.children(func(l helpers.ILogger) helpers.ILogger { return l.Named(name) })
+}
+
+func (ml *MultiLogger) children(child func(helpers.ILogger) helpers.ILogger) *MultiLogger {
+	loggers := make([]helpers.ILogger, len(ml.loggers))
+	for i := range ml.loggers {
+		loggers[i] = child(ml.loggers[i])
+	}
+	return &MultiLogger{loggers: loggers}
+}
+
+// Fatal logs the entry at the fatal level with all the loggers, syncs them and calls the fatal handler once.
+// The loggers which are not a helpers.FatalPrinter log it at the error level
+func (ml *MultiLogger) Fatal(msg string, details ...helpers.IDetails) {
+	ml.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry with all the loggers and syncs them without calling the fatal handler, see helpers.FatalPrinter
+func (ml *MultiLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		helpers.PrintFatal(l, msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Error(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Error(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Warning(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Warning(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Success(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Success(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Info(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Info(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Debug(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Debug(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) Start(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.Start(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.StopSuccess(msg, details...)
+	}
+}
+
+func (ml *MultiLogger) StopError(msg string, details ...helpers.IDetails) {
+	for _, l := range ml.loggers {
+		l.StopError(msg, details...)
+	}
+}
+
+func contains(outputs []io.Writer, w io.Writer) bool {
+	if !reflect.TypeOf(w).Comparable() {
+		return false
+	}
+	for i := range outputs {
+		if outputs[i] == w {
+			return true
+		}
+	}
+	return false
+}
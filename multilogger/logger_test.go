@@ -0,0 +1,86 @@
+package multilogger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/logfmtlogger"
+	"github.com/kubescape/go-logger/nonelogger"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiLogger(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+
+	prettyOut := &bytes.Buffer{}
+	pretty := prettylogger.NewPrettyLogger()
+	pretty.SetOutput(prettyOut)
+
+	logfmtOut := &bytes.Buffer{}
+	logfmt := logfmtlogger.NewLogfmtLogger()
+	logfmt.SetOutput(logfmtOut)
+	assert.NoError(t, logfmt.SetLevel("debug"))
+
+	logger := NewMultiLogger(pretty, logfmt)
+	assert.Equal(t, "debug", logger.GetLevel())
+
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234")).Ctx(context.Background())
+	child.Debug("debug")
+	child.Start("scanning")
+	child.StopSuccess("done")
+
+	assert.Equal(t, "[info] [scanner] scanning. scanID: 1234\n[success] [scanner] done. scanID: 1234\n", prettyOut.String())
+	assert.Regexp(t, regexp.MustCompile(`^time=\S+ level=debug logger=scanner msg=debug scanID=1234
+time=\S+ level=info logger=scanner event=start msg=scanning scanID=1234
+time=\S+ level=success logger=scanner event=stop_success msg=done scanID=1234
+$`), logfmtOut.String())
+}
+
+func TestMultiLoggerSetLevel(t *testing.T) {
+	logger := NewMultiLogger(prettylogger.NewPrettyLogger(), logfmtlogger.NewLogfmtLogger())
+	assert.NoError(t, logger.SetLevel("warning"))
+	for _, l := range logger.Loggers() {
+		assert.Equal(t, "warning", l.GetLevel())
+	}
+	assert.Error(t, logger.SetLevel("unknown"))
+}
+
+func TestMultiLoggerOutput(t *testing.T) {
+	assert.Equal(t, io.Discard, NewMultiLogger(nonelogger.NewNoneLogger()).GetOutput())
+
+	b := &bytes.Buffer{}
+	logger := NewMultiLogger(prettylogger.NewPrettyLogger(), logfmtlogger.NewLogfmtLogger(), nonelogger.NewNoneLogger())
+	logger.SetOutput(b)
+	assert.Equal(t, b, logger.GetOutput())
+	assert.Nil(t, logger.GetWriter())
+
+	logger.Loggers()[1].SetOutput(&bytes.Buffer{})
+	_, ok := logger.GetOutput().(*bytes.Buffer)
+	assert.False(t, ok)
+}
+
+func TestMultiLoggerFatal(t *testing.T) {
+	prettylogger.DisableColor(true)
+	defer prettylogger.EnableColor(true)
+	recorder, restore := helpers.SetFatalTestMode()
+	defer restore()
+
+	prettyOut := &bytes.Buffer{}
+	pretty := prettylogger.NewPrettyLogger()
+	pretty.SetOutput(prettyOut)
+	logfmtOut := &bytes.Buffer{}
+	logfmt := logfmtlogger.NewLogfmtLogger()
+	logfmt.SetOutput(logfmtOut)
+
+	// every logger logs the fatal entry, the fatal handler is called once even if the last logger prints nothing
+	NewMultiLogger(pretty, logfmt, nonelogger.NewNoneLogger()).Fatal("boom")
+	assert.Equal(t, "[fatal] boom\n", prettyOut.String())
+	assert.Contains(t, logfmtOut.String(), "level=fatal msg=boom")
+	assert.Equal(t, []string{"boom"}, recorder.Messages())
+}
@@ -2,6 +2,7 @@ package nonelogger
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"github.com/kubescape/go-logger/helpers"
@@ -13,18 +14,26 @@ type NoneLogger struct {
 }
 
 var _ helpers.ILogger = (*NoneLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*NoneLogger)(nil)
 
-func NewNoneLogger() *NoneLogger {
+// NewNoneLogger returns a logger printing nothing, the options are ignored
+func NewNoneLogger(_ ...helpers.Option) *NoneLogger {
 	return &NoneLogger{}
 }
 
 func (nl *NoneLogger) GetLevel() string                                    { return "" }
 func (nl *NoneLogger) Ctx(_ context.Context) helpers.ILogger               { return nl }
+func (nl *NoneLogger) With(details ...helpers.IDetails) helpers.ILogger    { return nl }
+func (nl *NoneLogger) Named(name string) helpers.ILogger                   { return nl }
 func (nl *NoneLogger) LoggerName() string                                  { return LoggerName }
 func (nl *NoneLogger) SetWriter(w *os.File)                                {}
 func (nl *NoneLogger) GetWriter() *os.File                                 { return nil }
+func (nl *NoneLogger) SetOutput(w io.Writer)                               {}
+func (nl *NoneLogger) GetOutput() io.Writer                                { return io.Discard }
 func (nl *NoneLogger) SetLevel(level string) error                         { return nil }
-func (nl *NoneLogger) Fatal(msg string, details ...helpers.IDetails)       {}
+func (nl *NoneLogger) Sync() error                                         { return nil }
+func (nl *NoneLogger) Close() error                                        { return nil }
+func (nl *NoneLogger) PrintFatal(msg string, details ...helpers.IDetails)  {}
 func (nl *NoneLogger) Error(msg string, details ...helpers.IDetails)       {}
 func (nl *NoneLogger) Warning(msg string, details ...helpers.IDetails)     {}
 func (nl *NoneLogger) Success(msg string, details ...helpers.IDetails)     {}
@@ -33,3 +42,8 @@ func (nl *NoneLogger) Debug(msg string, details ...helpers.IDetails)       {}
 func (nl *NoneLogger) Start(msg string, details ...helpers.IDetails)       {}
 func (nl *NoneLogger) StopSuccess(msg string, details ...helpers.IDetails) {}
 func (nl *NoneLogger) StopError(msg string, details ...helpers.IDetails)   {}
+
+// Fatal prints nothing and calls the fatal handler, the process exits as with the other loggers
+func (nl *NoneLogger) Fatal(msg string, details ...helpers.IDetails) {
+	helpers.HandleFatal(msg)
+}
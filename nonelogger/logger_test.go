@@ -0,0 +1,18 @@
+package nonelogger
+
+import (
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoneLoggerFatal(t *testing.T) {
+	recorder, restore := helpers.SetFatalTestMode()
+	defer restore()
+
+	logger := NewNoneLogger()
+	helpers.PrintFatal(logger, "printed nothing")
+	logger.With(helpers.String("key", "value")).Fatal("failed")
+	assert.Equal(t, []string{"failed"}, recorder.Messages())
+}
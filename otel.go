@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	logglobal "go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// OTLP protocol environment name, "grpc" or "http/protobuf". The default is "http/protobuf"
+	EnvOtelExporterOTLPProtocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	// OTLP endpoint environment name, e.g. "http://otel-collector:4318"
+	EnvOtelExporterOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	OTLPProtocolGRPC         = "grpc"
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// OTLP signals, used in the names of the signal specific environment variables, e.g. OTEL_EXPORTER_OTLP_TRACES_PROTOCOL
+const (
+	otlpTraces  = "TRACES"
+	otlpMetrics = "METRICS"
+	otlpLogs    = "LOGS"
+)
+
+var (
+	otlpMutex    sync.Mutex
+	otlpShutdown []func(context.Context) error
+)
+
+// InitOTLP configures OpenTelemetry to export traces, metrics and logs to any OpenTelemetry collector with the OTLP protocol,
+// and installs the providers and the W3C trace context propagator globally.
+// The exporters are configured by the standard OTEL_EXPORTER_OTLP_* environment variables (endpoint, protocol, headers, timeout, TLS...),
+// the resource by OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES, overriding serviceName, version and attrs.
+// An error is returned if the configuration is invalid, nothing is installed in that case.
+// It is required to call ShutdownOtel at the end of the main.
+//
+//	func main() {
+//	  ctx := context.Background()
+//	  if err := logger.InitOTLP(ctx, "<service>", "<version>"); err != nil {
+//	      logger.L().Fatal("failed to configure otel", helpers.Error(err))
+//	  }
+//	  defer logger.ShutdownOtel(ctx)
+//	  ...
+//	}
+func InitOTLP(ctx context.Context, serviceName, version string, attrs ...attribute.KeyValue) error {
+	for _, signal := range []string{"", otlpTraces, otlpMetrics, otlpLogs} {
+		if err := validateOTLPEndpoint(signalEnv(EnvOtelExporterOTLPEndpoint, signal)); err != nil {
+			return err
+		}
+	}
+
+	if serviceName != "" {
+		attrs = append(attrs, attribute.String("service.name", serviceName))
+	}
+	if version != "" {
+		attrs = append(attrs, attribute.String("service.version", version))
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attrs...),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create otel resource: %w", err)
+	}
+
+	tp, err := newOTLPTracerProvider(ctx, res)
+	if err != nil {
+		return err
+	}
+	mp, err := newOTLPMeterProvider(ctx, res)
+	if err != nil {
+		return errors.Join(err, tp.Shutdown(ctx))
+	}
+	lp, err := newOTLPLoggerProvider(ctx, res)
+	if err != nil {
+		return errors.Join(err, tp.Shutdown(ctx), mp.Shutdown(ctx))
+	}
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	logglobal.SetLoggerProvider(lp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	otlpMutex.Lock()
+	otlpShutdown = append(otlpShutdown, tp.Shutdown, mp.Shutdown, lp.Shutdown)
+	otlpMutex.Unlock()
+	return nil
+}
+
+// shutdownOTLP flushes and shuts down the providers installed by InitOTLP
+func shutdownOTLP(ctx context.Context) error {
+	otlpMutex.Lock()
+	shutdown := otlpShutdown
+	otlpShutdown = nil
+	otlpMutex.Unlock()
+
+	var errs []error
+	for _, f := range shutdown {
+		errs = append(errs, f(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func newOTLPTracerProvider(ctx context.Context, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+	protocol, err := otlpProtocol(otlpTraces)
+	if err != nil {
+		return nil, err
+	}
+	var exporter sdktrace.SpanExporter
+	switch protocol {
+	case OTLPProtocolGRPC:
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		exporter, err = otlptracehttp.New(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)), nil
+}
+
+func newOTLPMeterProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
+	protocol, err := otlpProtocol(otlpMetrics)
+	if err != nil {
+		return nil, err
+	}
+	var exporter sdkmetric.Exporter
+	switch protocol {
+	case OTLPProtocolGRPC:
+		exporter, err = otlpmetricgrpc.New(ctx)
+	default:
+		exporter, err = otlpmetrichttp.New(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp metric exporter: %w", err)
+	}
+	return sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)), sdkmetric.WithResource(res)), nil
+}
+
+func newOTLPLoggerProvider(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	protocol, err := otlpProtocol(otlpLogs)
+	if err != nil {
+		return nil, err
+	}
+	var exporter sdklog.Exporter
+	switch protocol {
+	case OTLPProtocolGRPC:
+		exporter, err = otlploggrpc.New(ctx)
+	default:
+		exporter, err = otlploghttp.New(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp log exporter: %w", err)
+	}
+	return sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)), sdklog.WithResource(res)), nil
+}
+
+// otlpProtocol returns the protocol of the signal, the signal specific environment variable overrides OTEL_EXPORTER_OTLP_PROTOCOL
+func otlpProtocol(signal string) (string, error) {
+	env := signalEnv(EnvOtelExporterOTLPProtocol, signal)
+	protocol := os.Getenv(env)
+	if protocol == "" {
+		env = EnvOtelExporterOTLPProtocol
+		protocol = os.Getenv(env)
+	}
+	switch protocol {
+	case "", OTLPProtocolHTTPProtobuf:
+		return OTLPProtocolHTTPProtobuf, nil
+	case OTLPProtocolGRPC:
+		return OTLPProtocolGRPC, nil
+	default:
+		return "", fmt.Errorf("unsupported otlp protocol '%s' in %s, expected '%s' or '%s'", protocol, env, OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf)
+	}
+}
+
+// validateOTLPEndpoint returns an error if the endpoint environment variable is set and is not an http or https URL.
+// The exporters ignore invalid endpoints and fall back to localhost
+func validateOTLPEndpoint(env string) error {
+	endpoint := os.Getenv(env)
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid otlp endpoint '%s' in %s: %w", endpoint, env, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid otlp endpoint '%s' in %s, expected an http or https URL", endpoint, env)
+	}
+	return nil
+}
+
+// signalEnv returns the name of the signal specific environment variable, e.g. OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+func signalEnv(env, signal string) string {
+	if signal == "" {
+		return env
+	}
+	return strings.Replace(env, "OTLP_", "OTLP_"+signal+"_", 1)
+}
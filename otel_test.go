@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func TestInitOTLPInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		val  string
+	}{
+		{name: "unsupported protocol", env: "OTEL_EXPORTER_OTLP_PROTOCOL", val: "http/json"},
+		{name: "unsupported signal protocol", env: "OTEL_EXPORTER_OTLP_LOGS_PROTOCOL", val: "udp"},
+		{name: "endpoint without scheme", env: "OTEL_EXPORTER_OTLP_ENDPOINT", val: "otel-collector:4318"},
+		{name: "signal endpoint with bad scheme", env: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", val: "ftp://otel-collector"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.val)
+			err := InitOTLP(context.Background(), "test", "v0.0.0")
+			assert.ErrorContains(t, err, tt.env)
+		})
+	}
+}
+
+func TestOtlpProtocol(t *testing.T) {
+	p, err := otlpProtocol(otlpTraces)
+	assert.NoError(t, err)
+	assert.Equal(t, OTLPProtocolHTTPProtobuf, p)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	p, err = otlpProtocol(otlpTraces)
+	assert.NoError(t, err)
+	assert.Equal(t, OTLPProtocolGRPC, p)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "http/protobuf")
+	p, err = otlpProtocol(otlpTraces)
+	assert.NoError(t, err)
+	assert.Equal(t, OTLPProtocolHTTPProtobuf, p)
+	p, err = otlpProtocol(otlpMetrics)
+	assert.NoError(t, err)
+	assert.Equal(t, OTLPProtocolGRPC, p)
+}
+
+func TestInitOTLP(t *testing.T) {
+	var mutex sync.Mutex
+	paths := map[string]bool{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		paths[r.URL.Path] = true
+		mutex.Unlock()
+	}))
+	defer collector.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	ctx := context.Background()
+	assert.NoError(t, InitOTLP(ctx, "test", "v0.0.0"))
+
+	_, span := otel.Tracer("test").Start(ctx, "span")
+	span.End()
+	ShutdownOtel(ctx)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.True(t, paths["/v1/traces"])
+	assert.True(t, paths["/v1/metrics"])
+}
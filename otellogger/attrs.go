@@ -0,0 +1,62 @@
+package otellogger
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"go.opentelemetry.io/otel/log"
+)
+
+// detailsToAttrs converts details to log attributes, groups are converted to maps
+func detailsToAttrs(details []helpers.IDetails) []log.KeyValue {
+	attrs := make([]log.KeyValue, 0, len(details))
+	for i := range details {
+		attrs = append(attrs, log.KeyValue{Key: details[i].Key(), Value: toValue(details[i].Value())})
+	}
+	return attrs
+}
+
+func toValue(value interface{}) log.Value {
+	switch v := value.(type) {
+	case string:
+		return log.StringValue(v)
+	case int:
+		return log.IntValue(v)
+	case int64:
+		return log.Int64Value(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return log.StringValue(strconv.FormatUint(v, 10))
+		}
+		return log.Int64Value(int64(v))
+	case float64:
+		return log.Float64Value(v)
+	case bool:
+		return log.BoolValue(v)
+	case time.Duration:
+		// durations are encoded in seconds, as in JSON
+		return log.Float64Value(v.Seconds())
+	case time.Time:
+		return log.StringValue(v.Format(time.RFC3339))
+	case helpers.Bytes:
+		return log.Int64Value(int64(v))
+	case []string:
+		values := make([]log.Value, 0, len(v))
+		for _, s := range v {
+			values = append(values, log.StringValue(s))
+		}
+		return log.SliceValue(values...)
+	case []int:
+		values := make([]log.Value, 0, len(v))
+		for _, i := range v {
+			values = append(values, log.IntValue(i))
+		}
+		return log.SliceValue(values...)
+	case []helpers.IDetails:
+		return log.MapValue(detailsToAttrs(v)...)
+	}
+	// errors, Stringers and other values are formatted
+	return log.StringValue(helpers.FormatValue(value))
+}
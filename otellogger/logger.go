@@ -0,0 +1,208 @@
+package otellogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+const LoggerName string = "otel"
+
+// ScopeName is the instrumentation scope of the log records
+const ScopeName = "github.com/kubescape/go-logger"
+
+// OtelLogger emits the entries as OpenTelemetry log records. The component name is added as the "logger" attribute and the Start/Stop events as the "event" attribute
+type OtelLogger struct {
+	logger log.Logger
+	root   *otelRoot
+	ctx    context.Context
+	fields []log.KeyValue // attributes added to every record
+	name   string         // component name, see Named
+}
+
+// otelRoot is shared by a logger and its children
+type otelRoot struct {
+	level    helpers.Level
+	provider log.LoggerProvider // nil for the global provider
+	caller   bool               // adds the call site, see helpers.WithCaller
+}
+
+var _ helpers.ILogger = (*OtelLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*OtelLogger)(nil)
+var _ helpers.CallerLogger = (*OtelLogger)(nil)
+
+// NewOtelLogger returns a logger emitting the records with the global logger provider, e.g. configured by InitOTLP.
+// The records emitted before the global provider is set are dropped. The options setting the output are ignored
+func NewOtelLogger(opts ...helpers.Option) *OtelLogger {
+	return NewOtelLoggerWithProvider(nil, opts...)
+}
+
+// NewOtelLoggerWithProvider returns a logger emitting the records with the provider, or with the global provider if nil
+func NewOtelLoggerWithProvider(provider log.LoggerProvider, opts ...helpers.Option) *OtelLogger {
+	o := helpers.ApplyOptions(opts...)
+	root := &otelRoot{level: helpers.InfoLevel, provider: provider, caller: o.Caller}
+	if o.Level != helpers.UnknownLevel {
+		root.level = o.Level
+	}
+	ol := &OtelLogger{logger: root.loggerProvider().Logger(ScopeName), root: root, ctx: context.Background()}
+	if len(o.Fields) > 0 {
+		ol.fields = detailsToAttrs(helpers.PrepareDetails(helpers.UnknownLevel, o.Fields, false))
+	}
+	return ol
+}
+
+func (ol *OtelLogger) GetLevel() string   { return ol.effectiveLevel().String() }
+func (ol *OtelLogger) LoggerName() string { return LoggerName }
+
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (ol *OtelLogger) Caller() bool { return ol.root.caller }
+
+// SetWriter is ignored, the records are exported by the logger provider
+func (ol *OtelLogger) SetWriter(w *os.File) {}
+func (ol *OtelLogger) GetWriter() *os.File  { return nil }
+
+// SetOutput is ignored, the records are exported by the logger provider
+func (ol *OtelLogger) SetOutput(w io.Writer) {}
+func (ol *OtelLogger) GetOutput() io.Writer  { return io.Discard }
+
+// Sync exports the pending records of providers supporting it, e.g. the SDK provider
+func (ol *OtelLogger) Sync() error { return ol.root.flush(ol.ctx) }
+
+// Close exports the pending records, the provider is not shut down as it may be shared (see ShutdownOtel)
+func (ol *OtelLogger) Close() error { return ol.Sync() }
+
+func (ol *OtelLogger) SetLevel(level string) error {
+	ol.root.level = helpers.ToLevel(level)
+	if ol.root.level == helpers.UnknownLevel {
+		return fmt.Errorf("level '%s' unknown", level)
+	}
+	return nil
+}
+
+// Ctx returns a child logger emitting the records with ctx, the records are correlated with the span of ctx
+func (ol *OtelLogger) Ctx(ctx context.Context) helpers.ILogger {
+	clone := *ol
+	clone.ctx = ctx
+	return &clone
+}
+
+// With returns a child logger adding the details as attributes of every record
+func (ol *OtelLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return ol
+	}
+	clone := *ol
+	clone.fields = append(append([]log.KeyValue{}, ol.fields...), detailsToAttrs(helpers.PrepareDetails(helpers.UnknownLevel, details, false))...)
+	return &clone
+}
+
+// Named returns a child logger of the component name. Its level can be set with helpers.SetComponentLevel
+func (ol *OtelLogger) Named(name string) helpers.ILogger {
+	clone := *ol
+	clone.name = helpers.JoinNames(ol.name, name)
+	return &clone
+}
+
+func (ol *OtelLogger) effectiveLevel() helpers.Level {
+	return helpers.EffectiveLevel(ol.name, ol.root.level)
+}
+
+func (ol *OtelLogger) Fatal(msg string, details ...helpers.IDetails) {
+	ol.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (ol *OtelLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.FatalLevel, msg, details)
+	ol.Sync()
+}
+func (ol *OtelLogger) Error(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.ErrorLevel, msg, details)
+}
+func (ol *OtelLogger) Warning(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.WarningLevel, msg, details)
+}
+func (ol *OtelLogger) Info(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.InfoLevel, msg, details)
+}
+func (ol *OtelLogger) Debug(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.DebugLevel, msg, details)
+}
+func (ol *OtelLogger) Success(msg string, details ...helpers.IDetails) {
+	ol.emit("", helpers.SuccessLevel, msg, details)
+}
+func (ol *OtelLogger) Start(msg string, details ...helpers.IDetails) {
+	ol.emit(helpers.StartEvent, helpers.InfoLevel, msg, details)
+}
+func (ol *OtelLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	ol.emit(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details)
+}
+func (ol *OtelLogger) StopError(msg string, details ...helpers.IDetails) {
+	ol.emit(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details)
+}
+
+func (ol *OtelLogger) emit(event string, level helpers.Level, msg string, details []helpers.IDetails) {
+	if level.Skip(ol.effectiveLevel()) {
+		return
+	}
+	var r log.Record
+	r.SetSeverity(ToSeverity(level))
+	if !ol.logger.Enabled(ol.ctx, r) {
+		return
+	}
+	now := time.Now()
+	r.SetTimestamp(now)
+	r.SetObservedTimestamp(now)
+	r.SetSeverityText(level.String())
+	r.SetBody(log.StringValue(msg))
+	if ol.name != "" {
+		r.AddAttributes(log.String("logger", ol.name))
+	}
+	if event != "" {
+		r.AddAttributes(log.String("event", event))
+	}
+	r.AddAttributes(ol.fields...)
+	r.AddAttributes(detailsToAttrs(helpers.PrepareDetails(level, details, ol.root.caller))...)
+	ol.logger.Emit(ol.ctx, r)
+}
+
+func (r *otelRoot) loggerProvider() log.LoggerProvider {
+	if r.provider != nil {
+		return r.provider
+	}
+	return global.GetLoggerProvider()
+}
+
+// flush exports the pending records of providers supporting it, e.g. the SDK provider
+func (r *otelRoot) flush(ctx context.Context) error {
+	if f, ok := r.loggerProvider().(interface{ ForceFlush(context.Context) error }); ok {
+		return f.ForceFlush(ctx)
+	}
+	return nil
+}
+
+// ToSeverity returns the OpenTelemetry severity of the level
+func ToSeverity(level helpers.Level) log.Severity {
+	switch level {
+	case helpers.DebugLevel:
+		return log.SeverityDebug
+	case helpers.InfoLevel:
+		return log.SeverityInfo
+	case helpers.SuccessLevel:
+		return log.SeverityInfo2
+	case helpers.WarningLevel:
+		return log.SeverityWarn
+	case helpers.ErrorLevel:
+		return log.SeverityError
+	case helpers.FatalLevel:
+		return log.SeverityFatal
+	}
+	return log.SeverityUndefined
+}
@@ -0,0 +1,77 @@
+package otellogger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recorded returns the records emitted to the recorder
+func recorded(recorder *logtest.Recorder) []logtest.EmittedRecord {
+	var records []logtest.EmittedRecord
+	for _, scope := range recorder.Result() {
+		records = append(records, scope.Records...)
+	}
+	return records
+}
+
+// attributes returns the attributes of the record formatted as strings
+func attributes(r logtest.EmittedRecord) map[string]string {
+	attrs := map[string]string{}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	return attrs
+}
+
+func TestOtelLogger(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := NewOtelLoggerWithProvider(recorder)
+	assert.Equal(t, "info", logger.GetLevel())
+
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234"))
+	child.Debug("not emitted")
+	child.Warning("slow", helpers.Duration("took", 1500*time.Millisecond), helpers.Error(errors.New("timeout")))
+	child.StopSuccess("done", helpers.Group("resource", helpers.String("kind", "Pod")))
+
+	records := recorded(recorder)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, log.SeverityWarn, records[0].Severity())
+		assert.Equal(t, "warning", records[0].SeverityText())
+		assert.Equal(t, "slow", records[0].Body().AsString())
+		assert.Equal(t, map[string]string{"logger": "scanner", "scanID": "1234", "took": "1.5", "error": "timeout"}, attributes(records[0]))
+
+		assert.Equal(t, log.SeverityInfo2, records[1].Severity())
+		assert.Equal(t, "done", records[1].Body().AsString())
+		assert.Equal(t, map[string]string{"logger": "scanner", "event": "stop_success", "scanID": "1234", "resource": "[kind:Pod]"}, attributes(records[1]))
+	}
+}
+
+func TestOtelLoggerCtx(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := NewOtelLoggerWithProvider(recorder)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{0x01}, SpanID: trace.SpanID{0x02}})
+	logger.Ctx(trace.ContextWithSpanContext(context.Background(), sc)).Error("failed")
+
+	records := recorded(recorder)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, sc, trace.SpanContextFromContext(records[0].Context()))
+	}
+}
+
+func TestToSeverity(t *testing.T) {
+	assert.Equal(t, log.SeverityDebug, ToSeverity(helpers.DebugLevel))
+	assert.Equal(t, log.SeverityInfo, ToSeverity(helpers.InfoLevel))
+	assert.Equal(t, log.SeverityError, ToSeverity(helpers.ErrorLevel))
+	assert.Equal(t, log.SeverityFatal, ToSeverity(helpers.FatalLevel))
+	assert.Equal(t, log.SeverityUndefined, ToSeverity(helpers.UnknownLevel))
+}
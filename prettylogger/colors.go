@@ -15,6 +15,36 @@ var prefixSuccess = color.New(color.Bold, color.FgHiGreen).FprintfFunc()
 var prefixDebug = color.New(color.Bold, color.FgWhite).FprintfFunc()
 var message = color.New().FprintfFunc()
 
+// colorPrefix returns the prefix function of the level, or the message function for UnknownLevel, colored if enabled is set or according to the terminal (see DisableColor) if nil
+func colorPrefix(l helpers.Level, enabled *bool) func(w io.Writer, format string, a ...interface{}) {
+	if enabled == nil {
+		return prefix(l)
+	}
+	c := color.New(attributes(l)...)
+	if *enabled {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+	return c.FprintfFunc()
+}
+
+func attributes(l helpers.Level) []color.Attribute {
+	switch l {
+	case helpers.DebugLevel:
+		return []color.Attribute{color.Bold, color.FgWhite}
+	case helpers.InfoLevel:
+		return []color.Attribute{color.Bold, color.FgCyan}
+	case helpers.SuccessLevel:
+		return []color.Attribute{color.Bold, color.FgHiGreen}
+	case helpers.WarningLevel:
+		return []color.Attribute{color.Bold, color.FgHiYellow}
+	case helpers.ErrorLevel, helpers.FatalLevel:
+		return []color.Attribute{color.Bold, color.FgHiRed}
+	}
+	return nil
+}
+
 func prefix(l helpers.Level) func(w io.Writer, format string, a ...interface{}) {
 	switch l {
 	case helpers.DebugLevel:
@@ -3,8 +3,10 @@ package prettylogger
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/kubescape/go-logger/helpers"
 )
@@ -12,38 +14,180 @@ import (
 const LoggerName string = "pretty"
 
 type PrettyLogger struct {
-	writer  *os.File
-	level   helpers.Level
-	mutex   sync.Mutex
+	writer     io.Writer
+	level      helpers.Level
+	format     string // helpers.TextFormat or helpers.JSONFormat, empty is text
+	timeFormat string // layout of the time, the text format prints no time if empty
+	color      *bool  // colors of the prefixes, according to the terminal if nil
+	caller     bool   // adds the call site, see helpers.WithCaller
+	mutex      sync.Mutex
+
+	parent *PrettyLogger      // root logger of a child created by With or Named, owns the level and the writer
+	fields []helpers.IDetails // details added to every entry
+	trace  []helpers.IDetails // trace details of the span of the context, replaced by Ctx
+	name   string             // component name, see Named
 }
 
 var _ helpers.ILogger = (*PrettyLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*PrettyLogger)(nil)
+var _ helpers.CallerLogger = (*PrettyLogger)(nil)
+
+// NewPrettyLogger returns a logger printing to stderr at the info level, unless set otherwise by the options
+func NewPrettyLogger(opts ...helpers.Option) *PrettyLogger {
+	o := helpers.ApplyOptions(opts...)
+	pl := &PrettyLogger{
+		writer:     os.Stderr, // default to stderr
+		level:      helpers.InfoLevel,
+		format:     helpers.TextFormat,
+		timeFormat: o.TimeFormat,
+		color:      o.Color,
+		caller:     o.Caller,
+		mutex:      sync.Mutex{},
+		fields:     o.Fields,
+	}
+	if o.Writer != nil {
+		pl.writer = o.Writer
+	}
+	if o.Level != helpers.UnknownLevel {
+		pl.level = o.Level
+	}
+	if o.Encoding != "" {
+		if format, err := helpers.ToFormat(o.Encoding); err == nil {
+			pl.format = format
+		}
+	}
+	return pl
+}
 
-func NewPrettyLogger() *PrettyLogger {
+func (pl *PrettyLogger) GetLevel() string     { return pl.effectiveLevel().String() }
+func (pl *PrettyLogger) SetWriter(w *os.File) { pl.SetOutput(w) }
+func (pl *PrettyLogger) LoggerName() string   { return LoggerName }
 
-	return &PrettyLogger{
-		writer:  os.Stderr, // default to stderr
-		level:   helpers.InfoLevel,
-		mutex:   sync.Mutex{},
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (pl *PrettyLogger) Caller() bool { return pl.root().caller }
+
+func (pl *PrettyLogger) GetWriter() *os.File {
+	f, _ := pl.GetOutput().(*os.File)
+	return f
+}
+
+func (pl *PrettyLogger) SetOutput(w io.Writer) {
+	root := pl.root()
+	root.mutex.Lock()
+	root.writer = w
+	root.mutex.Unlock()
+}
+
+func (pl *PrettyLogger) GetOutput() io.Writer {
+	root := pl.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return root.writer
+}
+
+// SetFormat sets the output format, "text" (default) or "json"
+func (pl *PrettyLogger) SetFormat(format string) error {
+	f, err := helpers.ToFormat(format)
+	if err != nil {
+		return err
 	}
+	root := pl.root()
+	root.mutex.Lock()
+	root.format = f
+	root.mutex.Unlock()
+	return nil
 }
 
-func (pl *PrettyLogger) GetLevel() string                      { return pl.level.String() }
-func (pl *PrettyLogger) SetWriter(w *os.File)                  { pl.writer = w }
-func (pl *PrettyLogger) GetWriter() *os.File                   { return pl.writer }
-func (pl *PrettyLogger) Ctx(_ context.Context) helpers.ILogger { return pl }
-func (pl *PrettyLogger) LoggerName() string                    { return LoggerName }
+// Sync commits the output to its storage if supported, e.g. files
+func (pl *PrettyLogger) Sync() error {
+	root := pl.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.SyncOutput(root.writer)
+}
+
+// Close closes the output if supported, os.Stdout and os.Stderr are not closed
+func (pl *PrettyLogger) Close() error {
+	root := pl.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	return helpers.CloseOutput(root.writer)
+}
 
 func (pl *PrettyLogger) SetLevel(level string) error {
-	pl.level = helpers.ToLevel(level)
-	if pl.level == helpers.UnknownLevel {
+	root := pl.root()
+	root.level = helpers.ToLevel(level)
+	if root.level == helpers.UnknownLevel {
 		return fmt.Errorf("level '%s' unknown", level)
 	}
 	return nil
 }
+
+// Ctx returns a child logger adding the trace_id, span_id and trace_flags of the span of ctx to every entry, replacing those of a previous Ctx
+func (pl *PrettyLogger) Ctx(ctx context.Context) helpers.ILogger {
+	trace := helpers.TraceDetails(ctx)
+	if trace == nil && pl.trace == nil {
+		return pl
+	}
+	return &PrettyLogger{
+		parent: pl.root(),
+		fields: pl.fields,
+		trace:  trace,
+		name:   pl.name,
+	}
+}
+
+// With returns a child logger printing the details with every entry. The child shares the level and the writer of its parent
+func (pl *PrettyLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return pl
+	}
+	return &PrettyLogger{
+		parent: pl.root(),
+		fields: append(append([]helpers.IDetails{}, pl.fields...), details...),
+		trace:  pl.trace,
+		name:   pl.name,
+	}
+}
+
+// Named returns a child logger of the component name. The component name is printed before the message and its level can be set with helpers.SetComponentLevel
+func (pl *PrettyLogger) Named(name string) helpers.ILogger {
+	return &PrettyLogger{
+		parent: pl.root(),
+		fields: pl.fields,
+		trace:  pl.trace,
+		name:   helpers.JoinNames(pl.name, name),
+	}
+}
+
+func (pl *PrettyLogger) root() *PrettyLogger {
+	if pl.parent != nil {
+		return pl.parent
+	}
+	return pl
+}
+
+func (pl *PrettyLogger) effectiveLevel() helpers.Level {
+	return helpers.EffectiveLevel(pl.name, pl.root().level)
+}
+
+// withFields prepends the details and the trace details of the logger to the entry details and prepares them for rendering (see helpers.PrepareDetails)
+func (pl *PrettyLogger) withFields(level helpers.Level, details []helpers.IDetails) []helpers.IDetails {
+	if len(pl.fields) == 0 && len(pl.trace) == 0 {
+		return helpers.PrepareDetails(level, details, pl.root().caller)
+	}
+	all := make([]helpers.IDetails, 0, len(pl.fields)+len(pl.trace)+len(details))
+	return helpers.PrepareDetails(level, append(append(append(all, pl.fields...), pl.trace...), details...), pl.root().caller)
+}
 func (pl *PrettyLogger) Fatal(msg string, details ...helpers.IDetails) {
+	pl.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (pl *PrettyLogger) PrintFatal(msg string, details ...helpers.IDetails) {
 	pl.print(helpers.FatalLevel, msg, details...)
-	os.Exit(1)
+	pl.Sync()
 }
 func (pl *PrettyLogger) Error(msg string, details ...helpers.IDetails) {
 	pl.print(helpers.ErrorLevel, msg, details...)
@@ -61,28 +205,58 @@ func (pl *PrettyLogger) Success(msg string, details ...helpers.IDetails) {
 	pl.print(helpers.SuccessLevel, msg, details...)
 }
 func (pl *PrettyLogger) Start(msg string, details ...helpers.IDetails) {
-	pl.print(helpers.InfoLevel, msg, details...)
+	pl.printEvent(helpers.StartEvent, helpers.InfoLevel, msg, details...)
 }
 func (pl *PrettyLogger) StopSuccess(msg string, details ...helpers.IDetails) {
-	pl.print(helpers.SuccessLevel, msg, details...)
+	pl.printEvent(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details...)
 }
 func (pl *PrettyLogger) StopError(msg string, details ...helpers.IDetails) {
-	pl.print(helpers.ErrorLevel, msg, details...)
+	pl.printEvent(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details...)
 }
 
 func (pl *PrettyLogger) print(level helpers.Level, msg string, details ...helpers.IDetails) {
-	if !level.Skip(pl.level) {
-		pl.mutex.Lock()
-		prefix(level)(pl.writer, "[%s] ", level.String())
-		message(pl.writer, fmt.Sprintf("%s\n", generateMessage(msg, details)))
-		pl.mutex.Unlock()
+	pl.printEvent("", level, msg, details...)
+}
+
+// printEvent prints the entry, the event is only printed by the JSON format
+func (pl *PrettyLogger) printEvent(event string, level helpers.Level, msg string, details ...helpers.IDetails) {
+	root := pl.root()
+	if !level.Skip(pl.effectiveLevel()) {
+		details = pl.withFields(level, details)
+		root.mutex.Lock()
+		defer root.mutex.Unlock()
+		if root.writer == nil {
+			return
+		}
+		if root.format == helpers.JSONFormat {
+			root.writer.Write(helpers.JSONLineWithTimeFormat(root.jsonTimeFormat(), level, time.Now(), pl.name, event, msg, details))
+			return
+		}
+		message := colorPrefix(helpers.UnknownLevel, root.color)
+		if root.timeFormat != "" {
+			message(root.writer, "%s ", time.Now().Format(root.timeFormat))
+		}
+		colorPrefix(level, root.color)(root.writer, "[%s] ", level.String())
+		if pl.name != "" {
+			message(root.writer, "[%s] ", pl.name)
+		}
+		message(root.writer, fmt.Sprintf("%s\n", generateMessage(msg, details)))
+	}
+}
+
+// jsonTimeFormat returns the layout of the time of the JSON format, RFC3339 by default
+func (pl *PrettyLogger) jsonTimeFormat() string {
+	if pl.timeFormat != "" {
+		return pl.timeFormat
 	}
+	return time.RFC3339
 }
 
 func detailsToString(details []helpers.IDetails) string {
+	details = helpers.Flatten(details)
 	s := ""
 	for i := range details {
-		s += fmt.Sprintf("%s: %v", details[i].Key(), details[i].Value())
+		s += fmt.Sprintf("%s: %s", details[i].Key(), helpers.FormatValue(details[i].Value()))
 		if i < len(details)-1 {
 			s += "; "
 		}
@@ -0,0 +1,159 @@
+package samplinglogger
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "sampling"
+
+// Config of a SamplingLogger
+type Config struct {
+	// Interval is the period the entries are counted over and the summaries are logged at, DefaultInterval if not set
+	Interval time.Duration
+	// First is the number of entries of a message and level logged each interval, DefaultFirst if neither First nor Thereafter is set
+	First int
+	// Thereafter logs every Thereafter-th entry of a message and level after the First ones, none if not set
+	Thereafter int
+}
+
+// DefaultInterval is the default sampling interval
+const DefaultInterval = time.Second
+
+// DefaultFirst is the default number of entries of a message and level logged each interval
+const DefaultFirst = 100
+
+// SamplingLogger limits the entries logged with the same message and level: each interval, the First entries are logged, then every Thereafter-th.
+// The number of suppressed entries is logged at the end of the interval, e.g. "suppressed 4,213 similar messages".
+// Fatal entries and the Start, StopSuccess and StopError events are never suppressed
+type SamplingLogger struct {
+	target  helpers.ILogger
+	sampler *sampler
+}
+
+var _ helpers.ILogger = (*SamplingLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*SamplingLogger)(nil)
+var _ helpers.CallerLogger = (*SamplingLogger)(nil)
+
+// NewSamplingLogger returns a logger sampling the entries logged with target. Call Close to stop the summaries
+func NewSamplingLogger(target helpers.ILogger, cfg Config) *SamplingLogger {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	cfg.First, cfg.Thereafter = max(cfg.First, 0), max(cfg.Thereafter, 0)
+	if cfg.First == 0 && cfg.Thereafter == 0 {
+		// a zero configuration would suppress all the entries
+		cfg.First = DefaultFirst
+	}
+	return &SamplingLogger{target: target, sampler: newSampler(cfg)}
+}
+
+// Target returns the logger the entries are logged with
+func (sl *SamplingLogger) Target() helpers.ILogger { return sl.target }
+
+// Suppressed returns the number of entries suppressed so far
+func (sl *SamplingLogger) Suppressed() uint64 { return sl.sampler.suppressed.Load() }
+
+func (sl *SamplingLogger) LoggerName() string          { return LoggerName }
+func (sl *SamplingLogger) GetLevel() string            { return sl.target.GetLevel() }
+func (sl *SamplingLogger) SetLevel(level string) error { return sl.target.SetLevel(level) }
+func (sl *SamplingLogger) SetWriter(w *os.File)        { sl.target.SetWriter(w) }
+func (sl *SamplingLogger) GetWriter() *os.File         { return sl.target.GetWriter() }
+func (sl *SamplingLogger) SetOutput(w io.Writer)       { sl.target.SetOutput(w) }
+func (sl *SamplingLogger) Caller() bool                { return helpers.LogsCaller(sl.target) }
+func (sl *SamplingLogger) GetOutput() io.Writer        { return sl.target.GetOutput() }
+
+// Sync logs the summaries of the current interval, which restarts, and syncs the target
+func (sl *SamplingLogger) Sync() error {
+	sl.sampler.summarize()
+	return sl.target.Sync()
+}
+
+// Close stops the summaries of the logger and of its children, logs the summaries of the current interval and closes the target
+func (sl *SamplingLogger) Close() error {
+	sl.sampler.close()
+	return sl.target.Close()
+}
+
+// Ctx returns a sampling logger of the child of the target, sharing the counters
+func (sl *SamplingLogger) Ctx(ctx context.Context) helpers.ILogger {
+	return &SamplingLogger{target: sl.target.Ctx(ctx), sampler: sl.sampler}
+}
+
+// With returns a sampling logger of the child of the target, sharing the counters. The details are not part of the sampled message
+func (sl *SamplingLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return sl
+	}
+	return &SamplingLogger{target: sl.target.With(details...), sampler: sl.sampler}
+}
+
+// Named returns a sampling logger of the child of the target, sharing the counters
+func (sl *SamplingLogger) Named(name string) helpers.ILogger {
+	return &SamplingLogger{target: sl.target.Named(name), sampler: sl.sampler}
+}
+
+func (sl *SamplingLogger) Fatal(msg string, details ...helpers.IDetails) {
+	sl.sampler.summarize()
+	sl.target.Fatal(msg, details...)
+}
+
+// PrintFatal logs the summaries and the fatal entry with the target without calling the fatal handler, see helpers.FatalPrinter
+func (sl *SamplingLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	sl.sampler.summarize()
+	helpers.PrintFatal(sl.target, msg, details...)
+}
+
+func (sl *SamplingLogger) Error(msg string, details ...helpers.IDetails) {
+	if sl.sample(helpers.ErrorLevel, msg) {
+		sl.target.Error(msg, details...)
+	}
+}
+
+func (sl *SamplingLogger) Warning(msg string, details ...helpers.IDetails) {
+	if sl.sample(helpers.WarningLevel, msg) {
+		sl.target.Warning(msg, details...)
+	}
+}
+
+func (sl *SamplingLogger) Success(msg string, details ...helpers.IDetails) {
+	if sl.sample(helpers.SuccessLevel, msg) {
+		sl.target.Success(msg, details...)
+	}
+}
+
+func (sl *SamplingLogger) Info(msg string, details ...helpers.IDetails) {
+	if sl.sample(helpers.InfoLevel, msg) {
+		sl.target.Info(msg, details...)
+	}
+}
+
+func (sl *SamplingLogger) Debug(msg string, details ...helpers.IDetails) {
+	if sl.sample(helpers.DebugLevel, msg) {
+		sl.target.Debug(msg, details...)
+	}
+}
+
+func (sl *SamplingLogger) Start(msg string, details ...helpers.IDetails) {
+	sl.target.Start(msg, details...)
+}
+
+func (sl *SamplingLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	sl.target.StopSuccess(msg, details...)
+}
+
+func (sl *SamplingLogger) StopError(msg string, details ...helpers.IDetails) {
+	sl.target.StopError(msg, details...)
+}
+
+// sample returns whether the entry is logged, the entries filtered by the target level are not counted
+func (sl *SamplingLogger) sample(level helpers.Level, msg string) bool {
+	if targetLevel := helpers.ToLevel(sl.target.GetLevel()); targetLevel != helpers.UnknownLevel && level.Skip(targetLevel) {
+		return false
+	}
+	return sl.sampler.sample(sl.target, level, msg)
+}
@@ -0,0 +1,92 @@
+package samplinglogger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTarget(t *testing.T) (*prettylogger.PrettyLogger, *bytes.Buffer) {
+	prettylogger.DisableColor(true)
+	t.Cleanup(func() { prettylogger.EnableColor(true) })
+
+	b := &bytes.Buffer{}
+	target := prettylogger.NewPrettyLogger()
+	target.SetOutput(b)
+	return target, b
+}
+
+func TestSamplingLogger(t *testing.T) {
+	target, b := newTarget(t)
+	logger := NewSamplingLogger(target, Config{Interval: time.Hour, First: 2, Thereafter: 3})
+	defer logger.Close()
+
+	child := logger.Named("scanner")
+	for i := 0; i < 10; i++ {
+		child.Warning("slow", helpers.Int("i", i))
+	}
+	child.Error("slow") // another level
+	child.Debug("filtered")
+	child.Start("scanning")
+	child.Start("scanning")
+
+	assert.Equal(t, `[warning] [scanner] slow. i: 0
+[warning] [scanner] slow. i: 1
+[warning] [scanner] slow. i: 4
+[warning] [scanner] slow. i: 7
+[error] [scanner] slow
+[info] [scanner] scanning
+[info] [scanner] scanning
+`, b.String())
+	assert.Equal(t, uint64(6), logger.Suppressed())
+
+	// the summary is logged at the end of the interval, which restarts
+	b.Reset()
+	assert.NoError(t, logger.Sync())
+	assert.Equal(t, "[warning] [scanner] suppressed 6 similar messages. message: slow; suppressed: 6\n", b.String())
+
+	b.Reset()
+	child.Warning("slow")
+	assert.NoError(t, logger.Sync())
+	assert.Equal(t, "[warning] [scanner] slow\n", b.String())
+}
+
+func TestSamplingLoggerInterval(t *testing.T) {
+	target, b := newTarget(t)
+	logger := NewSamplingLogger(target, Config{Interval: 10 * time.Millisecond, First: 1})
+	for i := 0; i < 10; i++ {
+		logger.Info("repeated")
+	}
+	time.Sleep(50 * time.Millisecond)
+	// stops the summaries before reading the buffer
+	assert.NoError(t, logger.Close())
+
+	// the summary is logged by the ticker, the entries may span two intervals
+	assert.True(t, strings.HasPrefix(b.String(), "[info] repeated\n"), b.String())
+	assert.Contains(t, b.String(), " similar messages. message: repeated; suppressed: ")
+}
+
+func TestSamplingLoggerZeroConfig(t *testing.T) {
+	target, b := newTarget(t)
+	logger := NewSamplingLogger(target, Config{})
+	defer logger.Close()
+
+	// the DefaultFirst entries are logged
+	for i := 0; i < DefaultFirst+1; i++ {
+		logger.Error("boom")
+	}
+	assert.Equal(t, DefaultFirst, strings.Count(b.String(), "[error] boom\n"))
+	assert.Equal(t, uint64(1), logger.Suppressed())
+}
+
+func TestFormatCount(t *testing.T) {
+	assert.Equal(t, "0", formatCount(0))
+	assert.Equal(t, "999", formatCount(999))
+	assert.Equal(t, "4,213", formatCount(4213))
+	assert.Equal(t, "1,000,000", formatCount(1000000))
+}
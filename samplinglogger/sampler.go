@@ -0,0 +1,136 @@
+package samplinglogger
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+type key struct {
+	level helpers.Level
+	msg   string
+}
+
+// counter of the entries of a message and level in the current interval
+type counter struct {
+	count      uint64
+	suppressed uint64
+	target     helpers.ILogger // logger of the last suppressed entry, logs the summary
+}
+
+// sampler counts the entries of each message and level, it is shared by a logger and its children
+type sampler struct {
+	cfg Config
+
+	mutex    sync.Mutex
+	counters map[key]*counter
+
+	suppressed atomic.Uint64
+	stop       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+func newSampler(cfg Config) *sampler {
+	s := &sampler{
+		cfg:      cfg,
+		counters: map[key]*counter{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// sample counts the entry and returns whether it is logged
+func (s *sampler) sample(target helpers.ILogger, level helpers.Level, msg string) bool {
+	k := key{level: level, msg: msg}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c := s.counters[k]
+	if c == nil {
+		c = &counter{}
+		s.counters[k] = c
+	}
+	c.count++
+	first, thereafter := uint64(s.cfg.First), uint64(s.cfg.Thereafter)
+	if c.count <= first || thereafter > 0 && (c.count-first)%thereafter == 0 {
+		return true
+	}
+	c.suppressed++
+	c.target = target
+	s.suppressed.Add(1)
+	return false
+}
+
+// run starts a new interval and logs the summaries of the previous one every interval, until the sampler is stopped
+func (s *sampler) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.summarize()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// summarize resets the counters and logs the number of suppressed entries of each message and level
+func (s *sampler) summarize() {
+	s.mutex.Lock()
+	counters := s.counters
+	s.counters = map[key]*counter{}
+	s.mutex.Unlock()
+
+	for k, c := range counters {
+		if c.suppressed == 0 {
+			continue
+		}
+		summary := "suppressed " + formatCount(c.suppressed) + " similar messages"
+		if c.suppressed == 1 {
+			summary = "suppressed 1 similar message"
+		}
+		details := []helpers.IDetails{helpers.String("message", k.msg), helpers.Uint64("suppressed", c.suppressed)}
+		switch k.level {
+		case helpers.ErrorLevel:
+			c.target.Error(summary, details...)
+		case helpers.WarningLevel:
+			c.target.Warning(summary, details...)
+		case helpers.SuccessLevel:
+			c.target.Success(summary, details...)
+		case helpers.InfoLevel:
+			c.target.Info(summary, details...)
+		case helpers.DebugLevel:
+			c.target.Debug(summary, details...)
+		}
+	}
+}
+
+// close stops the sampler and logs the summaries of the current interval
+func (s *sampler) close() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+	s.summarize()
+}
+
+// formatCount formats the count with thousands separators, e.g. 4,213
+func formatCount(n uint64) string {
+	s := strconv.FormatUint(n, 10)
+	b := make([]byte, 0, len(s)+len(s)/3)
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b = append(b, ',')
+		}
+		b = append(b, s[i])
+	}
+	return string(b)
+}
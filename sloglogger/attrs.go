@@ -0,0 +1,87 @@
+package sloglogger
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+// attrsToDetails converts slog attributes to details, groups are converted to helpers.Group
+func attrsToDetails(attrs []slog.Attr) []helpers.IDetails {
+	details := make([]helpers.IDetails, 0, len(attrs))
+	for _, a := range attrs {
+		details = appendAttr(details, a)
+	}
+	return details
+}
+
+func appendAttr(details []helpers.IDetails, a slog.Attr) []helpers.IDetails {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return details
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return append(details, helpers.String(a.Key, a.Value.String()))
+	case slog.KindInt64:
+		return append(details, helpers.Int64(a.Key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(details, helpers.Uint64(a.Key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(details, helpers.Float64(a.Key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(details, helpers.Bool(a.Key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(details, helpers.Duration(a.Key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(details, helpers.TimeValue(a.Key, a.Value.Time()))
+	case slog.KindGroup:
+		group := attrsToDetails(a.Value.Group())
+		if len(group) == 0 {
+			return details
+		}
+		if a.Key == "" {
+			// groups without key are inlined
+			return append(details, group...)
+		}
+		return append(details, helpers.Group(a.Key, group...))
+	}
+	return append(details, helpers.Interface(a.Key, a.Value.Any()))
+}
+
+// detailsToAttrs converts details to slog attributes, groups are converted to slog groups
+func detailsToAttrs(details []helpers.IDetails) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(details))
+	for i := range details {
+		attrs = append(attrs, detailToAttr(details[i]))
+	}
+	return attrs
+}
+
+func detailToAttr(detail helpers.IDetails) slog.Attr {
+	key := detail.Key()
+	switch v := detail.Value().(type) {
+	case string:
+		return slog.String(key, v)
+	case int:
+		return slog.Int(key, v)
+	case int64:
+		return slog.Int64(key, v)
+	case uint64:
+		return slog.Uint64(key, v)
+	case float64:
+		return slog.Float64(key, v)
+	case bool:
+		return slog.Bool(key, v)
+	case time.Duration:
+		return slog.Duration(key, v)
+	case time.Time:
+		return slog.Time(key, v)
+	case helpers.Bytes:
+		return slog.Int64(key, int64(v))
+	case []helpers.IDetails:
+		return slog.Attr{Key: key, Value: slog.GroupValue(detailsToAttrs(v)...)}
+	}
+	return slog.Any(key, detail.Value())
+}
@@ -0,0 +1,100 @@
+package sloglogger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Handler is a slog.Handler forwarding the records to a helpers.ILogger, so libraries logging with slog follow the configured logger:
+//
+//	slog.SetDefault(slog.New(sloglogger.NewHandler(logger.L())))
+//
+// Records are logged with the helpers level of their slog level (see FromSlogLevel), attributes are converted to details and groups to helpers.Group.
+// Records of LevelFatal and above are logged as errors, a slog record never exits the process
+type Handler struct {
+	logger helpers.ILogger
+	groups []string      // groups opened with WithGroup
+	attrs  [][]slog.Attr // attributes added in each group, attrs[i] belongs to groups[i]
+}
+
+func NewHandler(l helpers.ILogger) *Handler {
+	return &Handler{logger: l}
+}
+
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return !FromSlogLevel(level).Skip(helpers.ToLevel(h.logger.GetLevel()))
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	l := h.logger
+	if ctx != nil {
+		l = l.Ctx(ctx)
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	details := h.group(attrsToDetails(attrs))
+
+	switch FromSlogLevel(r.Level) {
+	case helpers.DebugLevel:
+		l.Debug(r.Message, details...)
+	case helpers.InfoLevel:
+		l.Info(r.Message, details...)
+	case helpers.SuccessLevel:
+		l.Success(r.Message, details...)
+	case helpers.WarningLevel:
+		l.Warning(r.Message, details...)
+	default:
+		l.Error(r.Message, details...)
+	}
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	if len(h.groups) == 0 {
+		return &Handler{logger: h.logger.With(attrsToDetails(attrs)...)}
+	}
+	clone := h.clone()
+	last := len(clone.attrs) - 1
+	clone.attrs[last] = append(append([]slog.Attr{}, clone.attrs[last]...), attrs...)
+	return clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := h.clone()
+	clone.groups = append(clone.groups, name)
+	clone.attrs = append(clone.attrs, nil)
+	return clone
+}
+
+func (h *Handler) clone() *Handler {
+	return &Handler{
+		logger: h.logger,
+		groups: append([]string{}, h.groups...),
+		attrs:  append([][]slog.Attr{}, h.attrs...),
+	}
+}
+
+// group nests the details of a record in the open groups, empty groups are omitted
+func (h *Handler) group(details []helpers.IDetails) []helpers.IDetails {
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		details = append(attrsToDetails(h.attrs[i]), details...)
+		if len(details) > 0 {
+			details = []helpers.IDetails{helpers.Group(h.groups[i], details...)}
+		}
+	}
+	return details
+}
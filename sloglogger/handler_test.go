@@ -0,0 +1,61 @@
+package sloglogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/kubescape/go-logger/prettylogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeLines(t *testing.T, b *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		entry := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		delete(entry, "time")
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestHandler(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := prettylogger.NewPrettyLogger()
+	l.SetOutput(b)
+	assert.NoError(t, l.SetFormat("json"))
+
+	logger := slog.New(NewHandler(l))
+	logger.Debug("not printed")
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelInfo))
+
+	logger.With("scanID", "1234").WithGroup("request").With("method", "GET").WithGroup("timing").
+		Warn("slow request", "took", time.Second, slog.Group("user", "id", 42))
+	logger.Log(context.Background(), LevelSuccess, "done", slog.Group("", "inline", true), slog.Group("nothing"))
+	logger.WithGroup("g").Error("failed", "err", "timeout")
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "warning", "msg": "slow request", "scanID": "1234", "request": map[string]interface{}{
+			"method": "GET",
+			"timing": map[string]interface{}{"took": float64(1), "user": map[string]interface{}{"id": float64(42)}},
+		}},
+		{"level": "success", "msg": "done", "inline": true},
+		{"level": "error", "msg": "failed", "g": map[string]interface{}{"err": "timeout"}},
+	}, decodeLines(t, b))
+}
+
+func TestLevels(t *testing.T) {
+	for _, level := range []helpers.Level{helpers.DebugLevel, helpers.InfoLevel, helpers.SuccessLevel, helpers.WarningLevel, helpers.ErrorLevel, helpers.FatalLevel} {
+		assert.Equal(t, level, FromSlogLevel(ToSlogLevel(level)))
+	}
+	assert.Equal(t, helpers.DebugLevel, FromSlogLevel(slog.LevelDebug-4))
+	assert.Equal(t, helpers.InfoLevel, FromSlogLevel(slog.LevelInfo+1))
+	assert.Equal(t, helpers.WarningLevel, FromSlogLevel(slog.LevelWarn+1))
+}
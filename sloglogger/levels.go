@@ -0,0 +1,61 @@
+package sloglogger
+
+import (
+	"log/slog"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+// slog levels of the helpers levels slog does not define
+const (
+	LevelSuccess = slog.LevelInfo + 2
+	LevelFatal   = slog.LevelError + 4
+)
+
+// ToSlogLevel returns the slog level of a helpers level
+func ToSlogLevel(l helpers.Level) slog.Level {
+	switch l {
+	case helpers.DebugLevel:
+		return slog.LevelDebug
+	case helpers.SuccessLevel:
+		return LevelSuccess
+	case helpers.WarningLevel:
+		return slog.LevelWarn
+	case helpers.ErrorLevel:
+		return slog.LevelError
+	case helpers.FatalLevel:
+		return LevelFatal
+	}
+	return slog.LevelInfo
+}
+
+// FromSlogLevel returns the helpers level of a slog level, levels between two slog levels are rounded down
+func FromSlogLevel(l slog.Level) helpers.Level {
+	switch {
+	case l < slog.LevelInfo:
+		return helpers.DebugLevel
+	case l < LevelSuccess:
+		return helpers.InfoLevel
+	case l < slog.LevelWarn:
+		return helpers.SuccessLevel
+	case l < slog.LevelError:
+		return helpers.WarningLevel
+	case l < LevelFatal:
+		return helpers.ErrorLevel
+	}
+	return helpers.FatalLevel
+}
+
+// replaceLevel names the success and fatal levels in the output of the slog handlers
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 || a.Key != slog.LevelKey {
+		return a
+	}
+	switch a.Value.Any() {
+	case LevelSuccess:
+		a.Value = slog.StringValue("SUCCESS")
+	case LevelFatal:
+		a.Value = slog.StringValue("FATAL")
+	}
+	return a
+}
@@ -0,0 +1,228 @@
+package sloglogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+)
+
+const LoggerName string = "slog"
+
+// SlogLogger logs with a slog.Handler. The component name is added as the "logger" attribute and the Start/Stop events as the "event" attribute
+type SlogLogger struct {
+	handler slog.Handler
+	root    *slogRoot
+	ctx     context.Context
+	trace   []slog.Attr // trace attributes of the span of ctx, replaced by Ctx
+	name    string      // component name, see Named
+}
+
+// slogRoot is shared by a logger and its children
+type slogRoot struct {
+	mutex    sync.Mutex
+	level    helpers.Level
+	writer   io.Writer // output of the default handler
+	external bool      // logging with a handler from NewSlogLoggerWithHandler, writer is not used
+	caller   bool      // adds the call site, see helpers.WithCaller
+}
+
+var _ helpers.ILogger = (*SlogLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*SlogLogger)(nil)
+var _ helpers.CallerLogger = (*SlogLogger)(nil)
+
+// NewSlogLogger returns a logger writing text records to stderr with the slog.TextHandler, unless set otherwise by the options.
+// The "json" encoding writes with the slog.JSONHandler
+func NewSlogLogger(opts ...helpers.Option) *SlogLogger {
+	o := helpers.ApplyOptions(opts...)
+	root := &slogRoot{level: helpers.InfoLevel, writer: os.Stderr} // default to stderr
+	if o.Writer != nil {
+		root.writer = o.Writer
+	}
+	handlerOptions := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceLevel}
+	if o.TimeFormat != "" {
+		handlerOptions.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+				return slog.String(slog.TimeKey, a.Value.Time().Format(o.TimeFormat))
+			}
+			return replaceLevel(groups, a)
+		}
+	}
+	var handler slog.Handler
+	if o.Encoding == helpers.JSONFormat {
+		handler = slog.NewJSONHandler(root, handlerOptions)
+	} else {
+		handler = slog.NewTextHandler(root, handlerOptions)
+	}
+	return newSlogLogger(handler, root, o)
+}
+
+// NewSlogLoggerWithHandler returns a logger on top of the handler. The handler must not forward its records to the logger itself (e.g. slog.Default() after calling slog.SetDefault with a Handler of this package).
+// The options setting the output of the default handler are ignored
+func NewSlogLoggerWithHandler(h slog.Handler, opts ...helpers.Option) *SlogLogger {
+	return newSlogLogger(h, &slogRoot{level: helpers.InfoLevel, external: true}, helpers.ApplyOptions(opts...))
+}
+
+func newSlogLogger(h slog.Handler, root *slogRoot, o helpers.Options) *SlogLogger {
+	if o.Level != helpers.UnknownLevel {
+		root.level = o.Level
+	}
+	root.caller = o.Caller
+	if len(o.Fields) > 0 {
+		h = h.WithAttrs(detailsToAttrs(helpers.PrepareDetails(helpers.UnknownLevel, o.Fields, false)))
+	}
+	return &SlogLogger{handler: h, root: root, ctx: context.Background()}
+}
+
+// Handler returns the slog handler of the logger
+func (sl *SlogLogger) Handler() slog.Handler { return sl.handler }
+
+func (sl *SlogLogger) GetLevel() string   { return sl.effectiveLevel().String() }
+func (sl *SlogLogger) LoggerName() string { return LoggerName }
+
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (sl *SlogLogger) Caller() bool { return sl.root.caller }
+func (sl *SlogLogger) SetWriter(w *os.File) {
+	sl.SetOutput(w)
+}
+
+func (sl *SlogLogger) GetWriter() *os.File {
+	f, _ := sl.GetOutput().(*os.File)
+	return f
+}
+
+// SetOutput sets the output of the default handler, it is ignored by loggers created with NewSlogLoggerWithHandler
+func (sl *SlogLogger) SetOutput(w io.Writer) {
+	sl.root.mutex.Lock()
+	defer sl.root.mutex.Unlock()
+	if !sl.root.external {
+		sl.root.writer = w
+	}
+}
+
+func (sl *SlogLogger) GetOutput() io.Writer {
+	sl.root.mutex.Lock()
+	defer sl.root.mutex.Unlock()
+	return sl.root.writer
+}
+
+// Sync commits the output of the default handler to its storage if supported, e.g. files
+func (sl *SlogLogger) Sync() error {
+	sl.root.mutex.Lock()
+	defer sl.root.mutex.Unlock()
+	return helpers.SyncOutput(sl.root.writer)
+}
+
+// Close closes the output of the default handler if supported, os.Stdout and os.Stderr are not closed
+func (sl *SlogLogger) Close() error {
+	sl.root.mutex.Lock()
+	defer sl.root.mutex.Unlock()
+	return helpers.CloseOutput(sl.root.writer)
+}
+
+func (sl *SlogLogger) SetLevel(level string) error {
+	sl.root.level = helpers.ToLevel(level)
+	if sl.root.level == helpers.UnknownLevel {
+		return fmt.Errorf("level '%s' unknown", level)
+	}
+	return nil
+}
+
+// Ctx returns a child logger passing ctx to the handler and adding the trace_id, span_id and trace_flags of the span of ctx to every entry, replacing those of a previous Ctx
+func (sl *SlogLogger) Ctx(ctx context.Context) helpers.ILogger {
+	clone := *sl
+	clone.ctx = ctx
+	clone.trace = detailsToAttrs(helpers.TraceDetails(ctx))
+	return &clone
+}
+
+// With returns a child logger adding the details as attributes of the handler
+func (sl *SlogLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return sl
+	}
+	clone := *sl
+	clone.handler = sl.handler.WithAttrs(detailsToAttrs(helpers.PrepareDetails(helpers.UnknownLevel, details, false)))
+	return &clone
+}
+
+// Named returns a child logger of the component name. Its level can be set with helpers.SetComponentLevel
+func (sl *SlogLogger) Named(name string) helpers.ILogger {
+	clone := *sl
+	clone.name = helpers.JoinNames(sl.name, name)
+	return &clone
+}
+
+func (sl *SlogLogger) effectiveLevel() helpers.Level {
+	return helpers.EffectiveLevel(sl.name, sl.root.level)
+}
+
+func (sl *SlogLogger) Fatal(msg string, details ...helpers.IDetails) {
+	sl.PrintFatal(msg, details...)
+	helpers.HandleFatal(msg)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (sl *SlogLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.FatalLevel, msg, details)
+	sl.Sync()
+}
+func (sl *SlogLogger) Error(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.ErrorLevel, msg, details)
+}
+func (sl *SlogLogger) Warning(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.WarningLevel, msg, details)
+}
+func (sl *SlogLogger) Info(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.InfoLevel, msg, details)
+}
+func (sl *SlogLogger) Debug(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.DebugLevel, msg, details)
+}
+func (sl *SlogLogger) Success(msg string, details ...helpers.IDetails) {
+	sl.log("", helpers.SuccessLevel, msg, details)
+}
+func (sl *SlogLogger) Start(msg string, details ...helpers.IDetails) {
+	sl.log(helpers.StartEvent, helpers.InfoLevel, msg, details)
+}
+func (sl *SlogLogger) StopSuccess(msg string, details ...helpers.IDetails) {
+	sl.log(helpers.StopSuccessEvent, helpers.SuccessLevel, msg, details)
+}
+func (sl *SlogLogger) StopError(msg string, details ...helpers.IDetails) {
+	sl.log(helpers.StopErrorEvent, helpers.ErrorLevel, msg, details)
+}
+
+func (sl *SlogLogger) log(event string, level helpers.Level, msg string, details []helpers.IDetails) {
+	if level.Skip(sl.effectiveLevel()) {
+		return
+	}
+	slogLevel := ToSlogLevel(level)
+	if !sl.handler.Enabled(sl.ctx, slogLevel) {
+		return
+	}
+	r := slog.NewRecord(time.Now(), slogLevel, msg, 0)
+	if sl.name != "" {
+		r.AddAttrs(slog.String("logger", sl.name))
+	}
+	if event != "" {
+		r.AddAttrs(slog.String("event", event))
+	}
+	r.AddAttrs(sl.trace...)
+	r.AddAttrs(detailsToAttrs(helpers.PrepareDetails(level, details, sl.root.caller))...)
+	sl.handler.Handle(sl.ctx, r)
+}
+
+// Write implements io.Writer for the default handler
+func (r *slogRoot) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.writer == nil {
+		return len(p), nil
+	}
+	return r.writer.Write(p)
+}
@@ -0,0 +1,73 @@
+package sloglogger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSlogLogger(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewSlogLogger()
+	logger.SetOutput(b)
+	assert.Equal(t, b, logger.GetOutput())
+
+	child := logger.Named("scanner").With(helpers.String("scanID", "1234"))
+	child.Debug("not printed")
+	child.Info("scanning", helpers.Group("resource", helpers.String("kind", "Pod")), helpers.ByteSize("size", 10))
+	child.StopSuccess("done")
+
+	assert.Regexp(t, regexp.MustCompile(`^time=\S+ level=INFO msg=scanning scanID=1234 logger=scanner resource.kind=Pod size=10
+time=\S+ level=SUCCESS msg=done scanID=1234 logger=scanner event=stop_success
+$`), b.String())
+}
+
+func TestSlogLoggerWithHandler(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewSlogLoggerWithHandler(slog.NewJSONHandler(b, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	assert.NoError(t, logger.SetLevel("debug"))
+	assert.Equal(t, "debug", logger.GetLevel())
+
+	// the handler level applies too
+	logger.Info("not printed")
+	logger.Warning("printed", helpers.Int("count", 2))
+	// the output of external handlers is not managed by the logger
+	logger.SetOutput(&bytes.Buffer{})
+	assert.Nil(t, logger.GetOutput())
+
+	assert.Regexp(t, regexp.MustCompile(`^\{"time":"\S+","level":"WARN","msg":"printed","count":2\}
+$`), b.String())
+}
+
+func TestNewSlogLoggerOptions(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewSlogLogger(
+		helpers.WithWriter(b),
+		helpers.WithEncoding(helpers.JSONFormat),
+		helpers.WithTimeFormat("2006"),
+		helpers.WithFields(helpers.String("pod", "kubescape-0")),
+	)
+	logger.Success("scanned")
+
+	assert.Regexp(t, regexp.MustCompile(`^\{"time":"\d{4}","level":"SUCCESS","msg":"scanned","pod":"kubescape-0"\}\n$`), b.String())
+}
+
+func TestSlogLoggerCtxTrace(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewSlogLogger(helpers.WithWriter(b))
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{0x01}, SpanID: trace.SpanID{0x02}})
+	child := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{0x01}, SpanID: trace.SpanID{0x03}})
+	// Ctx replaces the trace attributes of a previous Ctx
+	logger.Ctx(trace.ContextWithSpanContext(context.Background(), parent)).
+		Ctx(trace.ContextWithSpanContext(context.Background(), child)).Info("child")
+
+	assert.Regexp(t, regexp.MustCompile(`^time=\S+ level=INFO msg=child trace_id=01000000000000000000000000000000 span_id=0300000000000000 trace_flags=00
+$`), b.String())
+}
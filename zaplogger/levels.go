@@ -0,0 +1,64 @@
+package zaplogger
+
+import (
+	"github.com/kubescape/go-logger/helpers"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ zapcore.Core = (*componentCore)(nil)
+
+// componentCore filters entries by the level of their logger name (see helpers.SetComponentLevel), falling back to the level of the logger.
+// The wrapped core must enable all levels
+type componentCore struct {
+	zapcore.Core
+	level zap.AtomicLevel
+}
+
+func newComponentCore(core zapcore.Core, level zap.AtomicLevel) zapcore.Core {
+	return &componentCore{Core: core, level: level}
+}
+
+func (c *componentCore) Enabled(lvl zapcore.Level) bool {
+	min := c.level.Level()
+	if l, ok := helpers.MinComponentLevel(); ok && toZapLevel(l) < min {
+		min = toZapLevel(l)
+	}
+	return lvl >= min
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *componentCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= componentLevel(ent.LoggerName, c.level) {
+		return c.Core.Check(ent, ce)
+	}
+	return ce
+}
+
+// componentLevel returns the level of the named logger
+func componentLevel(name string, level zap.AtomicLevel) zapcore.Level {
+	if l, ok := helpers.ComponentLevel(name); ok {
+		return toZapLevel(l)
+	}
+	return level.Level()
+}
+
+func toZapLevel(l helpers.Level) zapcore.Level {
+	switch l {
+	case helpers.DebugLevel:
+		return zapcore.DebugLevel
+	case helpers.InfoLevel, helpers.SuccessLevel:
+		return zapcore.InfoLevel
+	case helpers.WarningLevel:
+		return zapcore.WarnLevel
+	case helpers.ErrorLevel:
+		return zapcore.ErrorLevel
+	case helpers.FatalLevel:
+		return zapcore.FatalLevel
+	}
+	return zapcore.InfoLevel
+}
@@ -2,7 +2,10 @@ package zaplogger
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/kubescape/go-logger/helpers"
 
@@ -14,41 +17,143 @@ import (
 const LoggerName string = "zap"
 
 type ZapLogger struct {
-	zapL *otelzap.Logger
-	cfg  zap.Config
+	zapL   *otelzap.Logger
+	cfg    zap.Config
+	out    *sink
+	fields []zapcore.Field // fields added to every entry, kept out of the core so otelzap does not duplicate them
+	caller bool            // adds the call site, see helpers.WithCaller
 }
 
 var _ helpers.ILogger = (*ZapLogger)(nil) // ensure all interface methods are here
+var _ helpers.FatalPrinter = (*ZapLogger)(nil)
+var _ helpers.CallerLogger = (*ZapLogger)(nil)
 
-func NewZapLogger() *ZapLogger {
+// NewZapLogger returns a logger writing JSON entries to stderr at the info level, unless set otherwise by the options.
+// The call site and the stack traces are added by go-logger (see helpers.WithCaller and helpers.SetStacktrace), they are disabled in zap
+func NewZapLogger(opts ...helpers.Option) *ZapLogger {
+	o := helpers.ApplyOptions(opts...)
 	ec := zap.NewProductionEncoderConfig()
 	ec.EncodeTime = zapcore.RFC3339TimeEncoder
+	if o.TimeFormat != "" {
+		ec.EncodeTime = zapcore.TimeEncoderOfLayout(o.TimeFormat)
+	}
 	cfg := zap.NewProductionConfig()
 	cfg.DisableCaller = true
 	cfg.DisableStacktrace = true
 	cfg.Encoding = "json"
+	if o.Encoding == "console" {
+		cfg.Encoding = o.Encoding
+	}
+	// the colors would be escape sequences in the JSON strings
+	if o.Color != nil && *o.Color && cfg.Encoding == "console" {
+		ec.EncodeLevel = zapcore.LowercaseColorLevelEncoder
+	}
 	cfg.EncoderConfig = ec
+	if o.Level != helpers.UnknownLevel {
+		cfg.Level.SetLevel(toZapLevel(o.Level))
+	}
 
-	zapLogger, err := cfg.Build()
-	if err != nil {
-		panic(err)
+	out := newSink(os.Stderr) // default to stderr
+	if o.Writer != nil {
+		out.set(o.Writer)
 	}
 	return &ZapLogger{
-		zapL: otelzap.New(zapLogger, otelzap.WithMinLevel(zap.InfoLevel)),
-		cfg:  cfg,
+		zapL:   newOtelZap(newZap(cfg, out)),
+		cfg:    cfg,
+		out:    out,
+		fields: appendZapFields(helpers.UnknownLevel, false, nil, o.Fields),
+		caller: o.Caller,
 	}
 }
-func (zl *ZapLogger) GetLevel() string     { return zl.cfg.Level.Level().String() }
-func (zl *ZapLogger) SetWriter(w *os.File) {}
-func (zl *ZapLogger) GetWriter() *os.File  { return nil }
+
+// newZap builds the zap logger of the configuration around the sink. Levels are checked by the component core, so the core itself enables all of them
+func newZap(cfg zap.Config, out zapcore.WriteSyncer) *zap.Logger {
+	var encoder zapcore.Encoder
+	if cfg.Encoding == "console" {
+		encoder = zapcore.NewConsoleEncoder(cfg.EncoderConfig)
+	} else {
+		encoder = zapcore.NewJSONEncoder(cfg.EncoderConfig)
+	}
+	core := zapcore.NewCore(encoder, out, zap.LevelEnablerFunc(func(zapcore.Level) bool { return true }))
+	if cfg.Sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+	return zap.New(newComponentCore(core, cfg.Level), zap.ErrorOutput(zapcore.Lock(os.Stderr)), zap.WithFatalHook(fatalHook{}))
+}
+
+// fatalHook calls the fatal handler of helpers once zap wrote the fatal entry
+type fatalHook struct{}
+
+func (fatalHook) OnWrite(ce *zapcore.CheckedEntry, _ []zapcore.Field) {
+	helpers.HandleFatal(ce.Message)
+}
+
+// noFatalHook does not call the fatal handler, see PrintFatal
+type noFatalHook struct{}
+
+func (noFatalHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+func newOtelZap(zapLogger *zap.Logger) *otelzap.Logger {
+	return otelzap.New(zapLogger, otelzap.WithMinLevel(zap.InfoLevel))
+}
+
+func (zl *ZapLogger) GetLevel() string {
+	return componentLevel(zl.zapL.Logger.Name(), zl.cfg.Level).String()
+}
+func (zl *ZapLogger) SetWriter(w *os.File)  { zl.out.set(w) }
+func (zl *ZapLogger) GetWriter() *os.File   { return zl.out.file() }
+func (zl *ZapLogger) SetOutput(w io.Writer) { zl.out.set(w) }
+func (zl *ZapLogger) GetOutput() io.Writer  { return zl.out.get() }
+
+// Ctx returns a logger attaching the warnings and errors to the span of ctx and adding the trace_id, span_id and trace_flags of the span to every entry
 func (zl *ZapLogger) Ctx(ctx context.Context) helpers.ILogger {
 	l := zl.zapL.Ctx(ctx)
 	return &ZapLoggerWithCtx{
-		zapL: &l,
-		cfg:  zl.cfg,
+		zapL:   &l,
+		cfg:    zl.cfg,
+		out:    zl.out,
+		caller: zl.caller,
+		fields: appendZapFields(helpers.UnknownLevel, false, zl.fields, helpers.TraceDetails(ctx)),
 	}
 }
 func (zl *ZapLogger) LoggerName() string { return LoggerName }
+
+// Caller reports whether the logger adds the call site of its entries even if it is not enabled by helpers.SetCaller, see helpers.WithCaller
+func (zl *ZapLogger) Caller() bool { return zl.caller }
+
+// Sync flushes the zap logger and commits the output to its storage if supported, e.g. files
+func (zl *ZapLogger) Sync() error { return zl.zapL.Sync() }
+
+// Close syncs and closes the output if supported, os.Stdout and os.Stderr are not closed
+func (zl *ZapLogger) Close() error {
+	zl.zapL.Sync()
+	return zl.out.close()
+}
+
+// With returns a child logger encoding the details with every entry. The child shares the level of its parent
+func (zl *ZapLogger) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return zl
+	}
+	return &ZapLogger{
+		zapL:   zl.zapL,
+		cfg:    zl.cfg,
+		out:    zl.out,
+		caller: zl.caller,
+		fields: appendZapFields(helpers.UnknownLevel, false, zl.fields, details),
+	}
+}
+
+// Named returns a child logger of the component name, encoded in the "logger" field. Its level can be set with helpers.SetComponentLevel
+func (zl *ZapLogger) Named(name string) helpers.ILogger {
+	return &ZapLogger{
+		zapL:   newOtelZap(zl.zapL.Logger.Named(name)),
+		cfg:    zl.cfg,
+		out:    zl.out,
+		caller: zl.caller,
+		fields: zl.fields,
+	}
+}
 func (zl *ZapLogger) SetLevel(level string) error {
 	l := zapcore.Level(1)
 	err := l.Set(level)
@@ -58,45 +163,119 @@ func (zl *ZapLogger) SetLevel(level string) error {
 	return err
 }
 func (zl *ZapLogger) Fatal(msg string, details ...helpers.IDetails) {
-	zl.zapL.Fatal(msg, detailsToZapFields(details)...)
+	zl.zapL.Fatal(msg, appendZapFields(helpers.FatalLevel, zl.caller, zl.fields, details)...)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (zl *ZapLogger) PrintFatal(msg string, details ...helpers.IDetails) {
+	zl.zapL.Logger.WithOptions(zap.WithFatalHook(noFatalHook{})).Fatal(msg, appendZapFields(helpers.FatalLevel, zl.caller, zl.fields, details)...)
+	zl.Sync()
 }
 
 func (zl *ZapLogger) Error(msg string, details ...helpers.IDetails) {
-	zl.zapL.Error(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.ErrorLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.ErrorLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) Warning(msg string, details ...helpers.IDetails) {
-	zl.zapL.Warn(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.WarnLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.WarningLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) Success(msg string, details ...helpers.IDetails) {
-	zl.zapL.Info(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.SuccessLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) Info(msg string, details ...helpers.IDetails) {
-	zl.zapL.Info(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) Debug(msg string, details ...helpers.IDetails) {
-	zl.zapL.Debug(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.DebugLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.DebugLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) Start(msg string, details ...helpers.IDetails) {
-	zl.zapL.Info(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) StopSuccess(msg string, details ...helpers.IDetails) {
-	zl.zapL.Info(msg, detailsToZapFields(details)...)
+	if ce := zl.zapL.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.SuccessLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLogger) StopError(msg string, details ...helpers.IDetails) {
-	zl.zapL.Info(msg, detailsToZapFields(details)...)
+	// logged at the info level, its details are prepared as such
+	if ce := zl.zapL.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func detailsToZapFields(details []helpers.IDetails) []zapcore.Field {
 	zapFields := []zapcore.Field{}
 	for i := range details {
-		zapFields = append(zapFields, zap.Any(details[i].Key(), details[i].Value()))
+		zapFields = append(zapFields, detailToZapField(details[i]))
 	}
 	return zapFields
 }
+
+// detailToZapField encodes the typed details with their zap field, other details are reflected by zap.Any
+func detailToZapField(detail helpers.IDetails) zapcore.Field {
+	key := detail.Key()
+	switch d := detail.(type) {
+	case *helpers.StringObj:
+		return zap.String(key, d.Value().(string))
+	case *helpers.IntObj:
+		return zap.Int(key, d.Value().(int))
+	case *helpers.BoolObj:
+		return zap.Bool(key, d.Value().(bool))
+	case *helpers.Int64Obj:
+		return zap.Int64(key, d.Value().(int64))
+	case *helpers.Uint64Obj:
+		return zap.Uint64(key, d.Value().(uint64))
+	case *helpers.Float64Obj:
+		return zap.Float64(key, d.Value().(float64))
+	case *helpers.DurationObj:
+		return zap.Duration(key, d.Value().(time.Duration))
+	case *helpers.TimeObj:
+		return zap.Time(key, d.Value().(time.Time))
+	case *helpers.StringsObj:
+		return zap.Strings(key, d.Value().([]string))
+	case *helpers.IntsObj:
+		return zap.Ints(key, d.Value().([]int))
+	case *helpers.ByteSizeObj:
+		return zap.Int64(key, int64(d.Value().(helpers.Bytes)))
+	case *helpers.GroupObj:
+		return zap.Object(key, groupMarshaler(d.Details()))
+	case *helpers.StringerObj:
+		if v, ok := d.Value().(fmt.Stringer); ok {
+			return zap.Stringer(key, v)
+		}
+	}
+	return zap.Any(key, detail.Value())
+}
+
+// groupMarshaler encodes a group of details as a nested object
+func groupMarshaler(details []helpers.IDetails) zapcore.ObjectMarshalerFunc {
+	return func(enc zapcore.ObjectEncoder) error {
+		for _, field := range detailsToZapFields(details) {
+			field.AddTo(enc)
+		}
+		return nil
+	}
+}
+
+// appendZapFields returns the logger fields followed by the details of an entry of the level, prepared by helpers.PrepareDetails
+func appendZapFields(level helpers.Level, caller bool, fields []zapcore.Field, details []helpers.IDetails) []zapcore.Field {
+	return append(append(make([]zapcore.Field, 0, len(fields)+len(details)), fields...), detailsToZapFields(helpers.PrepareDetails(level, details, caller))...)
+}
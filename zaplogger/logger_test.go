@@ -0,0 +1,321 @@
+package zaplogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubescape/go-logger/helpers"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeLines(t *testing.T, b *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		delete(entry, "ts")
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestZapLoggerSetOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	assert.Equal(t, os.Stderr, logger.GetWriter())
+
+	logger.SetOutput(b)
+	assert.Equal(t, b, logger.GetOutput())
+	assert.Nil(t, logger.GetWriter())
+
+	logger.Info("hello", helpers.String("key", "value"))
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "info", "msg": "hello", "key": "value"},
+	}, decodeLines(t, b))
+}
+
+func TestZapLoggerWithAndNamed(t *testing.T) {
+	defer helpers.ResetComponentLevels()
+
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	child := logger.With(helpers.String("scanID", "1234")).Named("scanner")
+	// children write to the output of their parent
+	logger.SetOutput(b)
+
+	assert.NoError(t, helpers.SetComponentLevel("scanner.rbac", "debug"))
+	child.Debug("not printed")
+	child.Named("rbac").Debug("rbac", helpers.Int("roles", 2))
+	child.Warning("warning")
+	assert.Equal(t, "debug", child.Named("rbac").GetLevel())
+	assert.Equal(t, "info", child.GetLevel())
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "debug", "logger": "scanner.rbac", "msg": "rbac", "scanID": "1234", "roles": float64(2)},
+		{"level": "warn", "logger": "scanner", "msg": "warning", "scanID": "1234"},
+	}, decodeLines(t, b))
+}
+
+func TestDetailToZapField(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+
+	logger.Info("typed",
+		helpers.Bool("ok", true),
+		helpers.Int64("count", 1<<40),
+		helpers.Uint64("id", 7),
+		helpers.Float64("ratio", 0.25),
+		helpers.Duration("took", 1500*time.Millisecond),
+		helpers.TimeValue("at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		helpers.Strings("namespaces", []string{"default"}),
+		helpers.Ints("ports", []int{80, 443}),
+		helpers.ByteSize("size", 1536),
+		helpers.Stringer("threshold", helpers.WarningLevel),
+		helpers.Stringer("nil", nil),
+	)
+	assert.Equal(t, []map[string]interface{}{{
+		"level":      "info",
+		"msg":        "typed",
+		"ok":         true,
+		"count":      float64(1 << 40),
+		"id":         float64(7),
+		"ratio":      0.25,
+		"took":       1.5,
+		"at":         "2024-01-02T03:04:05Z",
+		"namespaces": []interface{}{"default"},
+		"ports":      []interface{}{float64(80), float64(443)},
+		"size":       float64(1536),
+		"threshold":  "warning",
+		"nil":        nil,
+	}}, decodeLines(t, b))
+}
+
+type resource struct {
+	kind, name string
+}
+
+func (r resource) MarshalDetails() []helpers.IDetails {
+	return []helpers.IDetails{helpers.String("kind", r.kind), helpers.String("name", r.name)}
+}
+
+func TestZapLoggerGroup(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+
+	logger.With(helpers.Object("resource", resource{kind: "Pod", name: "nginx"})).Info("control",
+		helpers.Group("result", helpers.String("id", "C-0001"), helpers.Group("score", helpers.Int("value", 5))),
+	)
+	assert.Equal(t, []map[string]interface{}{{
+		"level":    "info",
+		"msg":      "control",
+		"resource": map[string]interface{}{"kind": "Pod", "name": "nginx"},
+		"result":   map[string]interface{}{"id": "C-0001", "score": map[string]interface{}{"value": float64(5)}},
+	}}, decodeLines(t, b))
+}
+
+func TestZapLoggerCtxTrace(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	logger.With(helpers.String("scanID", "1234")).Ctx(trace.ContextWithSpanContext(context.Background(), sc)).Warning("traced")
+	logger.Ctx(context.Background()).Info("not traced")
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "warn", "msg": "traced", "scanID": "1234", "trace_id": "01000000000000000000000000000000", "span_id": "0200000000000000", "trace_flags": "00"},
+		{"level": "info", "msg": "not traced"},
+	}, decodeLines(t, b))
+}
+
+func TestZapLoggerRedaction(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+
+	logger.With(helpers.String("token", "abc")).Info("pulling",
+		helpers.Secret("registryPassword", "hunter2"),
+		helpers.Group("registry", helpers.String("name", "quay.io"), helpers.String("config", `{"auth":"dXNlcjpwYXNz"}`)),
+		helpers.Error(errors.New("login failed: password=hunter2")),
+	)
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "info", "msg": "pulling", "token": "[REDACTED]", "registryPassword": "[REDACTED]",
+			"registry": map[string]interface{}{"name": "quay.io", "config": `{"auth":"[REDACTED]"}`}, "error": "login failed: password=[REDACTED]"},
+	}, decodeLines(t, b))
+}
+
+func TestZapLoggerErrors(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+
+	helpers.SetErrorStacks(true)
+	defer helpers.SetErrorStacks(false)
+
+	err := helpers.Error(fmt.Errorf("scan: %w", errors.New("timeout")))
+	logger.Warning("retrying", err)
+	logger.Error("giving up", err)
+
+	lines := decodeLines(t, b)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, map[string]interface{}{"level": "warn", "msg": "retrying", "error": "scan: timeout", "errorChain": []interface{}{"scan: timeout", "timeout"}}, lines[0])
+	assert.Equal(t, "scan: timeout", lines[1]["error"])
+	assert.Equal(t, []interface{}{"scan: timeout", "timeout"}, lines[1]["errorChain"])
+	assert.Contains(t, lines[1]["errorStack"], "zaplogger.TestZapLoggerErrors")
+
+	// StopError is logged at the info level, without the stacks of the error entries
+	helpers.SetStacktrace(true)
+	defer helpers.SetStacktrace(false)
+	b.Reset()
+	logger.StopError("scan failed", err)
+	logger.Ctx(context.Background()).StopError("scan failed", err)
+	lines = decodeLines(t, b)
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Equal(t, map[string]interface{}{"level": "info", "msg": "scan failed", "error": "scan: timeout", "errorChain": []interface{}{"scan: timeout", "timeout"}}, line)
+	}
+
+	// the stack trace is not added to the errors carrying their stack
+	b.Reset()
+	logger.Error("giving up", err)
+	logger.Error("giving up", helpers.String("reason", "timeout"))
+	lines = decodeLines(t, b)
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0]["errorStack"], "zaplogger.TestZapLoggerErrors")
+	assert.NotContains(t, lines[0], "stacktrace")
+	assert.Contains(t, lines[1]["stacktrace"], "zaplogger.TestZapLoggerErrors")
+}
+
+func TestZapLoggerSyncClose(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "zap.log")
+	f, err := os.Create(filename)
+	assert.NoError(t, err)
+
+	logger := NewZapLogger()
+	logger.SetOutput(f)
+	logger.Ctx(context.Background()).Info("message")
+	assert.NoError(t, logger.Sync())
+	assert.NoError(t, logger.Close())
+	// the file is closed
+	assert.Error(t, f.Close())
+
+	b, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"msg":"message"`)
+}
+
+func TestZapLoggerFatal(t *testing.T) {
+	recorder, restore := helpers.SetFatalTestMode()
+	defer restore()
+
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+	logger.Named("scanner").Fatal("failed")
+	logger.Ctx(context.Background()).Fatal("failed with ctx")
+
+	assert.Equal(t, []string{"failed", "failed with ctx"}, recorder.Messages())
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "fatal", "logger": "scanner", "msg": "failed"},
+		{"level": "fatal", "msg": "failed with ctx"},
+	}, decodeLines(t, b))
+}
+
+func TestZapLoggerPrintFatal(t *testing.T) {
+	recorder, restore := helpers.SetFatalTestMode()
+	defer restore()
+
+	b := &bytes.Buffer{}
+	logger := NewZapLogger()
+	logger.SetOutput(b)
+	helpers.PrintFatal(logger.Named("scanner"), "failed")
+	helpers.PrintFatal(logger.Ctx(context.Background()), "failed with ctx")
+
+	// logged at the fatal level without calling the fatal handler
+	assert.Empty(t, recorder.Messages())
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "fatal", "logger": "scanner", "msg": "failed"},
+		{"level": "fatal", "msg": "failed with ctx"},
+	}, decodeLines(t, b))
+}
+
+func TestNewZapLoggerOptions(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger(
+		helpers.WithWriter(b),
+		helpers.WithLevel(helpers.WarningLevel),
+		helpers.WithTimeFormat(time.DateOnly),
+		helpers.WithFields(helpers.String("pod", "kubescape-0")),
+		helpers.WithCaller(true),
+	)
+	assert.Equal(t, "warn", logger.GetLevel())
+	logger.Info("filtered")
+	logger.With(helpers.String("scanID", "1234")).Warning("slow")
+
+	entry := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &entry))
+	_, err := time.Parse(time.DateOnly, entry["ts"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "kubescape-0", entry["pod"])
+	assert.Equal(t, "1234", entry["scanID"])
+	assert.Equal(t, "github.com/kubescape/go-logger/zaplogger.TestNewZapLoggerOptions", entry["function"])
+
+	b.Reset()
+	logger = NewZapLogger(helpers.WithWriter(b), helpers.WithEncoding("console"))
+	logger.Info("console")
+	assert.Contains(t, b.String(), "\tinfo\tconsole\n")
+
+	// the colors apply only to the console encoding
+	b.Reset()
+	logger = NewZapLogger(helpers.WithWriter(b), helpers.WithEncoding("console"), helpers.WithColor(true))
+	logger.Info("colored")
+	assert.Contains(t, b.String(), "\x1b[34minfo\x1b[0m\tcolored\n")
+
+	b.Reset()
+	logger = NewZapLogger(helpers.WithWriter(b), helpers.WithColor(true))
+	logger.Info("json")
+	logger.Error("json")
+	assert.NotContains(t, b.String(), "\x1b")
+	assert.NotContains(t, b.String(), `\u001b`)
+	assert.Len(t, decodeLines(t, b), 2)
+}
+
+func TestZapLoggerDisabledLevel(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := NewZapLogger(helpers.WithWriter(b), helpers.WithLevel(helpers.WarningLevel))
+	ctxLogger := logger.Ctx(context.Background())
+	details := []helpers.IDetails{helpers.String("key", "value"), helpers.Error(errors.New("failed"))}
+
+	// the details of the disabled entries are not prepared
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		logger.Debug("debug", details...)
+		logger.Info("info", details...)
+		ctxLogger.Info("info", details...)
+		ctxLogger.Success("success", details...)
+	}))
+	helpers.SetComponentLevel("scanner", "debug")
+	defer helpers.ResetComponentLevels()
+	logger.Named("scanner").Debug("debug", details...)
+	ctxLogger.Named("scanner").Debug("debug", details...)
+	ctxLogger.Named("rbac").Warning("warning")
+	ctxLogger.Named("rbac").Error("error")
+	assert.Len(t, decodeLines(t, b), 4)
+}
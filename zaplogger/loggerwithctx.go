@@ -2,6 +2,7 @@ package zaplogger
 
 import (
 	"context"
+	"io"
 	"os"
 	"strings"
 
@@ -13,17 +14,68 @@ import (
 )
 
 var _ helpers.ILogger = (*ZapLoggerWithCtx)(nil)
+var _ helpers.FatalPrinter = (*ZapLoggerWithCtx)(nil)
+var _ helpers.CallerLogger = (*ZapLoggerWithCtx)(nil)
 
 type ZapLoggerWithCtx struct {
-	zapL *otelzap.LoggerWithCtx
-	cfg  zap.Config
+	zapL   *otelzap.LoggerWithCtx
+	cfg    zap.Config
+	out    *sink
+	fields []zapcore.Field
+	caller bool // adds the call site, see helpers.WithCaller
 }
 
-func (zl *ZapLoggerWithCtx) GetLevel() string                      { return zl.cfg.Level.Level().String() }
-func (zl *ZapLoggerWithCtx) SetWriter(w *os.File)                  {}
-func (zl *ZapLoggerWithCtx) GetWriter() *os.File                   { return nil }
+func (zl *ZapLoggerWithCtx) GetLevel() string {
+	return componentLevel(zl.zapL.ZapLogger().Name(), zl.cfg.Level).String()
+}
+func (zl *ZapLoggerWithCtx) SetWriter(w *os.File)                  { zl.out.set(w) }
+func (zl *ZapLoggerWithCtx) GetWriter() *os.File                   { return zl.out.file() }
+func (zl *ZapLoggerWithCtx) SetOutput(w io.Writer)                 { zl.out.set(w) }
+func (zl *ZapLoggerWithCtx) GetOutput() io.Writer                  { return zl.out.get() }
 func (zl *ZapLoggerWithCtx) Ctx(_ context.Context) helpers.ILogger { return zl }
 func (zl *ZapLoggerWithCtx) LoggerName() string                    { return LoggerName }
+func (zl *ZapLoggerWithCtx) Caller() bool                          { return zl.caller }
+
+// Sync flushes the zap logger and commits the output to its storage if supported, e.g. files
+func (zl *ZapLoggerWithCtx) Sync() error { return zl.zapL.ZapLogger().Sync() }
+
+// Close syncs and closes the output if supported, os.Stdout and os.Stderr are not closed
+func (zl *ZapLoggerWithCtx) Close() error {
+	zl.zapL.ZapLogger().Sync()
+	return zl.out.close()
+}
+
+// With returns a child logger encoding the details with every entry. The child shares the level and the context of its parent
+func (zl *ZapLoggerWithCtx) With(details ...helpers.IDetails) helpers.ILogger {
+	if len(details) == 0 {
+		return zl
+	}
+	return &ZapLoggerWithCtx{
+		zapL:   zl.zapL,
+		cfg:    zl.cfg,
+		out:    zl.out,
+		caller: zl.caller,
+		fields: appendZapFields(helpers.UnknownLevel, false, zl.fields, details),
+	}
+}
+
+// Named returns a child logger of the component name, encoded in the "logger" field. Its level can be set with helpers.SetComponentLevel
+func (zl *ZapLoggerWithCtx) Named(name string) helpers.ILogger {
+	l := newOtelZap(zl.zapL.ZapLogger().Named(name)).Ctx(zl.zapL.Context())
+	return &ZapLoggerWithCtx{
+		zapL:   &l,
+		cfg:    zl.cfg,
+		out:    zl.out,
+		caller: zl.caller,
+		fields: zl.fields,
+	}
+}
+
+// enabled reports whether the entries of the level are logged, checked before preparing their details
+func (zl *ZapLoggerWithCtx) enabled(level zapcore.Level) bool {
+	return level >= componentLevel(zl.zapL.ZapLogger().Name(), zl.cfg.Level)
+}
+
 func (zl *ZapLoggerWithCtx) SetLevel(level string) error {
 	l := zapcore.Level(1)
 	err := l.Set(level)
@@ -33,40 +85,66 @@ func (zl *ZapLoggerWithCtx) SetLevel(level string) error {
 	return err
 }
 func (zl *ZapLoggerWithCtx) Fatal(msg string, details ...helpers.IDetails) {
-	zl.zapL.Fatal(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	zl.zapL.Fatal(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), appendZapFields(helpers.FatalLevel, zl.caller, zl.fields, details)...)
+}
+
+// PrintFatal logs the fatal entry and syncs the output without calling the fatal handler, see helpers.FatalPrinter
+func (zl *ZapLoggerWithCtx) PrintFatal(msg string, details ...helpers.IDetails) {
+	zl.zapL.ZapLogger().WithOptions(zap.WithFatalHook(noFatalHook{})).Fatal(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), appendZapFields(helpers.FatalLevel, zl.caller, zl.fields, details)...)
+	zl.Sync()
 }
 
 func (zl *ZapLoggerWithCtx) Error(msg string, details ...helpers.IDetails) {
-	zl.zapL.Error(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	// the level is checked first, otelzap attaches the entry to the span before writing it
+	if !zl.enabled(zapcore.ErrorLevel) {
+		return
+	}
+	zl.zapL.Error(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), appendZapFields(helpers.ErrorLevel, zl.caller, zl.fields, details)...)
 }
 
 func (zl *ZapLoggerWithCtx) Warning(msg string, details ...helpers.IDetails) {
-	zl.zapL.Warn(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if !zl.enabled(zapcore.WarnLevel) {
+		return
+	}
+	zl.zapL.Warn(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), appendZapFields(helpers.WarningLevel, zl.caller, zl.fields, details)...)
 }
 
 func (zl *ZapLoggerWithCtx) Success(msg string, details ...helpers.IDetails) {
 	// calling ZapLogger() to get the underlying logger and not attach the log to the span
-	zl.zapL.ZapLogger().Info(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if ce := zl.zapL.ZapLogger().Check(zapcore.InfoLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.SuccessLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLoggerWithCtx) Info(msg string, details ...helpers.IDetails) {
 	// calling ZapLogger() to get the underlying logger and not attach the log to the span
-	zl.zapL.ZapLogger().Info(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if ce := zl.zapL.ZapLogger().Check(zapcore.InfoLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLoggerWithCtx) Debug(msg string, details ...helpers.IDetails) {
 	// calling ZapLogger() to get the underlying logger and not attach the log to the span
-	zl.zapL.ZapLogger().Debug(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if ce := zl.zapL.ZapLogger().Check(zapcore.DebugLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.DebugLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLoggerWithCtx) Start(msg string, details ...helpers.IDetails) {
-	zl.zapL.ZapLogger().Info(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if ce := zl.zapL.ZapLogger().Check(zapcore.InfoLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLoggerWithCtx) StopSuccess(msg string, details ...helpers.IDetails) {
-	zl.zapL.ZapLogger().Info(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	if ce := zl.zapL.ZapLogger().Check(zapcore.InfoLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.SuccessLevel, zl.caller, zl.fields, details)...)
+	}
 }
 
 func (zl *ZapLoggerWithCtx) StopError(msg string, details ...helpers.IDetails) {
-	zl.zapL.ZapLogger().Info(strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString), detailsToZapFields(details)...)
+	// logged at the info level, its details are prepared as such
+	if ce := zl.zapL.ZapLogger().Check(zapcore.InfoLevel, strings.ToValidUTF8(msg, helpers.InvalidUtf8ReplacementString)); ce != nil {
+		ce.Write(appendZapFields(helpers.InfoLevel, zl.caller, zl.fields, details)...)
+	}
 }
@@ -0,0 +1,61 @@
+package zaplogger
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kubescape/go-logger/helpers"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ zapcore.WriteSyncer = (*sink)(nil)
+
+// sink is the output of the zap core. It is shared by a logger and its children so SetOutput applies to all of them
+type sink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func newSink(w io.Writer) *sink {
+	return &sink{writer: w}
+}
+
+func (s *sink) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.writer == nil {
+		return len(p), nil
+	}
+	return s.writer.Write(p)
+}
+
+func (s *sink) Sync() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return helpers.SyncOutput(s.writer)
+}
+
+// close syncs and closes the writer if supported, os.Stdout and os.Stderr are not closed
+func (s *sink) close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return helpers.CloseOutput(s.writer)
+}
+
+func (s *sink) set(w io.Writer) {
+	s.mutex.Lock()
+	s.writer = w
+	s.mutex.Unlock()
+}
+
+func (s *sink) get() io.Writer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.writer
+}
+
+func (s *sink) file() *os.File {
+	f, _ := s.get().(*os.File)
+	return f
+}